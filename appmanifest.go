@@ -1,10 +1,13 @@
 package steamutils
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
-	"strings"
+	"strconv"
+	"time"
 
 	"github.com/iancoleman/orderedmap"
 )
@@ -16,21 +19,19 @@ import (
 // applications. Returns a slice of InstalledApp with full metadata including
 // name, size, build ID, and installed content depots.
 //
-// Returns an error if the library configuration cannot be parsed or if manifest
-// files cannot be read. Applications with unreadable manifest files are skipped.
+// Returns an error if the library configuration cannot be parsed. Apps whose
+// manifest cannot be read or parsed are returned with ManifestError set, and
+// apps in a library that cannot be reached, such as unmounted removable media,
+// with Unavailable set. Apps libraryfolders.vdf still lists after their
+// manifest was deleted are left out.
 //
 // WARNING: This method was generated by an LLM. Thoroughly test before production use.
 func (steamreader *SteamReader) GetAllInstalledApps() ([]InstalledApp, error) {
 	var installedApps []InstalledApp
 
-	libFoldersVal, exists := steamreader.libraryVdfMap.Get("libraryfolders")
-	if !exists {
-		return nil, fmt.Errorf("libraryfolders key not found in the VDF data")
-	}
-
-	libFolders, ok := libFoldersVal.(*orderedmap.OrderedMap)
-	if !ok {
-		return nil, fmt.Errorf("libraryfolders is not of the expected type")
+	libFolders, err := steamreader.libraryFolders()
+	if err != nil {
+		return nil, err
 	}
 
 	// Iterate through each library folder
@@ -56,7 +57,7 @@ func (steamreader *SteamReader) GetAllInstalledApps() ([]InstalledApp, error) {
 			continue
 		}
 
-		libraryPath = strings.ReplaceAll(libraryPath, "\\\\", "\\")
+		libraryPath = steamreader.libraryPath(libraryPath)
 
 		// Get the apps from this library
 		appsVal, exists := library.Get("apps")
@@ -69,14 +70,29 @@ func (steamreader *SteamReader) GetAllInstalledApps() ([]InstalledApp, error) {
 			continue
 		}
 
+		_, available := libraryStorage(libraryPath)
+
 		// Read each app's manifest file
 		for _, appID := range apps.Keys() {
+			if !available {
+				app := InstalledApp{AppID: appID, LibraryPath: libraryPath, Unavailable: true}
+				app.SizeOnDisk, _ = strconv.ParseInt(vdfString(apps, appID), 10, 64)
+				installedApps = append(installedApps, app)
+				continue
+			}
+
 			app, err := readAppManifest(libraryPath, appID)
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
 			if err != nil {
-				// Skip apps that can't be read
+				app = InstalledApp{AppID: appID, LibraryPath: libraryPath, ManifestError: err.Error()}
+				app.SizeOnDisk, _ = strconv.ParseInt(vdfString(apps, appID), 10, 64)
+				installedApps = append(installedApps, app)
 				continue
 			}
 			app.LibraryPath = libraryPath
+			app.FullPath = steamreader.formatPath(app.FullPath)
 			installedApps = append(installedApps, app)
 		}
 	}
@@ -90,8 +106,8 @@ func (steamreader *SteamReader) GetAllInstalledApps() ([]InstalledApp, error) {
 // its manifest file to extract metadata. Returns a pointer to InstalledApp with
 // all available information including name, size, build ID, and installed depots.
 //
-// Returns an error if the application is not found in any library or if the
-// manifest file cannot be read.
+// Returns an error wrapping ErrAppNotFound if the application is not found in
+// any library, or an error if the manifest file cannot be read.
 //
 // WARNING: This method was generated by an LLM. Thoroughly test before production use.
 func (steamreader *SteamReader) GetInstalledAppByID(appID string) (*InstalledApp, error) {
@@ -106,6 +122,7 @@ func (steamreader *SteamReader) GetInstalledAppByID(appID string) (*InstalledApp
 	}
 
 	app.LibraryPath = libraryPath
+	app.FullPath = steamreader.formatPath(app.FullPath)
 	return &app, nil
 }
 
@@ -133,94 +150,138 @@ func readAppManifest(libraryPath, appID string) (InstalledApp, error) {
 		return InstalledApp{}, fmt.Errorf("AppState is not of expected type")
 	}
 
-	app := InstalledApp{
-		AppID: appID,
+	app, err := appFromAppState(appState)
+	if err != nil {
+		return InstalledApp{}, fmt.Errorf("%s: %w", manifestPath, err)
 	}
 
-	// Extract app details from the manifest
-	if nameVal, exists := appState.Get("name"); exists {
-		if name, ok := nameVal.(string); ok {
-			app.Name = name
-		}
+	app.AppID = appID
+	if app.InstallDir != "" {
+		app.FullPath = filepath.Join(libraryPath, "steamapps", "common", app.InstallDir)
 	}
 
-	if installDirVal, exists := appState.Get("installdir"); exists {
-		if installDir, ok := installDirVal.(string); ok {
-			app.InstallDir = installDir
-			// Construct full path
-			app.FullPath = filepath.Join(libraryPath, "steamapps", "common", installDir)
-		}
+	return app, nil
+}
+
+// appFromAppState extracts the typed fields of an AppState block. Numeric
+// fields that are present but malformed or out of range are errors.
+func appFromAppState(appState *orderedmap.OrderedMap) (InstalledApp, error) {
+	app := InstalledApp{
+		AppID:      vdfString(appState, "appid"),
+		Name:       vdfString(appState, "name"),
+		InstallDir: vdfString(appState, "installdir"),
+		BuildID:    vdfString(appState, "buildid"),
+		Raw:        appState,
 	}
 
-	if buildIDVal, exists := appState.Get("buildid"); exists {
-		if buildID, ok := buildIDVal.(string); ok {
-			app.BuildID = buildID
+	var err error
+	for _, field := range []struct {
+		key    string
+		target *int64
+	}{
+		{"SizeOnDisk", &app.SizeOnDisk},
+		{"StateFlags", &app.StateFlags},
+		{"BytesToDownload", &app.BytesToDownload},
+		{"BytesDownloaded", &app.BytesDownloaded},
+	} {
+		if *field.target, err = manifestInt(appState, field.key); err != nil {
+			return InstalledApp{}, err
 		}
 	}
 
-	if sizeVal, exists := appState.Get("SizeOnDisk"); exists {
-		if sizeStr, ok := sizeVal.(string); ok {
-			fmt.Sscanf(sizeStr, "%d", &app.SizeOnDisk)
-		}
+	behavior, err := manifestInt(appState, "AutoUpdateBehavior")
+	if err != nil {
+		return InstalledApp{}, err
 	}
+	app.AutoUpdateBehavior = AutoUpdateBehavior(behavior)
 
-	if lastUpdatedVal, exists := appState.Get("LastUpdated"); exists {
-		if lastUpdatedStr, ok := lastUpdatedVal.(string); ok {
-			fmt.Sscanf(lastUpdatedStr, "%d", &app.LastUpdated)
-		}
+	background, err := manifestInt(appState, "AllowOtherDownloadsWhileRunning")
+	if err != nil {
+		return InstalledApp{}, err
 	}
+	app.AllowOtherDownloadsWhileRunning = BackgroundDownloads(background)
 
-	if lastPlayedVal, exists := appState.Get("LastPlayed"); exists {
-		if lastPlayedStr, ok := lastPlayedVal.(string); ok {
-			fmt.Sscanf(lastPlayedStr, "%d", &app.LastPlayed)
-		}
+	if app.LastUpdated, err = manifestTime(appState, "LastUpdated"); err != nil {
+		return InstalledApp{}, err
+	}
+	if app.LastPlayed, err = manifestTime(appState, "LastPlayed"); err != nil {
+		return InstalledApp{}, err
 	}
 
 	// Parse InstalledDepots
-	if installedDepotsVal, exists := appState.Get("InstalledDepots"); exists {
-		if installedDepots, ok := installedDepotsVal.(*orderedmap.OrderedMap); ok {
-			for _, depotID := range installedDepots.Keys() {
-				depotVal, exists := installedDepots.Get(depotID)
-				if !exists {
-					continue
-				}
-
-				depotMap, ok := depotVal.(*orderedmap.OrderedMap)
-				if !ok {
-					continue
-				}
-
-				depot := InstalledDepot{
-					DepotID: depotID,
-				}
-
-				// Get manifest ID
-				if manifestVal, exists := depotMap.Get("manifest"); exists {
-					if manifest, ok := manifestVal.(string); ok {
-						depot.Manifest = manifest
-					}
-				}
-
-				// Get size
-				if sizeVal, exists := depotMap.Get("size"); exists {
-					if sizeStr, ok := sizeVal.(string); ok {
-						fmt.Sscanf(sizeStr, "%d", &depot.Size)
-					}
-				}
-
-				// Get DLC App ID if this is DLC
-				if dlcAppIDVal, exists := depotMap.Get("dlcappid"); exists {
-					if dlcAppID, ok := dlcAppIDVal.(string); ok {
-						depot.DLCAppID = dlcAppID
-					}
-				}
-
-				app.InstalledDepots = append(app.InstalledDepots, depot)
-			}
+	installedDepotsVal, _ := vdfLookup(appState, "InstalledDepots")
+	installedDepots, ok := installedDepotsVal.(*orderedmap.OrderedMap)
+	if !ok {
+		return app, nil
+	}
+
+	for _, depotKey := range installedDepots.Keys() {
+		depotVal, _ := installedDepots.Get(depotKey)
+		depotMap, ok := depotVal.(*orderedmap.OrderedMap)
+		if !ok {
+			continue
+		}
+
+		depotID, err := strconv.ParseUint(depotKey, 10, 64)
+		if err != nil {
+			return InstalledApp{}, fmt.Errorf("invalid depot ID %q: %w", depotKey, err)
 		}
+
+		depot := InstalledDepot{
+			DepotID:  depotID,
+			DLCAppID: vdfString(depotMap, "dlcappid"),
+		}
+		if depot.Manifest, err = manifestUint(depotMap, "manifest"); err != nil {
+			return InstalledApp{}, fmt.Errorf("depot %s: %w", depotKey, err)
+		}
+		if depot.Size, err = manifestInt(depotMap, "size"); err != nil {
+			return InstalledApp{}, fmt.Errorf("depot %s: %w", depotKey, err)
+		}
+
+		app.InstalledDepots = append(app.InstalledDepots, depot)
 	}
 
 	return app, nil
 }
 
+// manifestInt parses the signed integer stored under key. A missing or empty
+// value is 0.
+func manifestInt(m *orderedmap.OrderedMap, key string) (int64, error) {
+	value := vdfString(m, key)
+	if value == "" {
+		return 0, nil
+	}
+
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %w", key, value, err)
+	}
+	return n, nil
+}
+
+// manifestUint parses the unsigned integer stored under key, such as a 64-bit
+// manifest GID. A missing or empty value is 0.
+func manifestUint(m *orderedmap.OrderedMap, key string) (uint64, error) {
+	value := vdfString(m, key)
+	if value == "" {
+		return 0, nil
+	}
+
+	n, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %w", key, value, err)
+	}
+	return n, nil
+}
+
+// manifestTime parses the Unix timestamp stored under key. A missing value or
+// 0 yields the zero time.
+func manifestTime(m *orderedmap.OrderedMap, key string) (time.Time, error) {
+	seconds, err := manifestInt(m, key)
+	if err != nil || seconds == 0 {
+		return time.Time{}, err
+	}
+	return time.Unix(seconds, 0), nil
+}
+
 // The code in this file was made by an LLM, use in production is highly discouraged as unexpected results may occur. The code in this file is not vetted for stability or edge cases.
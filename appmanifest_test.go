@@ -0,0 +1,56 @@
+package steamutils
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGetAllInstalledAppsReportsInvalidManifests(t *testing.T) {
+	steamPath := makeSteamFixture(t, t.TempDir())
+	steamapps := filepath.Join(steamPath, "steamapps")
+
+	vdf := "\"libraryfolders\"\n{\n\t\"0\"\n\t{\n\t\t\"path\"\t\t\"" + escapeVDFString(steamPath) + "\"\n" +
+		"\t\t\"apps\"\n\t\t{\n\t\t\t\"100\"\t\t\"1000\"\n\t\t\t\"200\"\t\t\"2000\"\n\t\t\t\"300\"\t\t\"3000\"\n\t\t}\n\t}\n}\n"
+	files := map[string]string{
+		"libraryfolders.vdf":  vdf,
+		"appmanifest_100.acf": "\"AppState\"\n{\n\t\"appid\"\t\t\"100\"\n\t\"name\"\t\t\"Valid\"\n\t\"StateFlags\"\t\t\"4\"\n}\n",
+		"appmanifest_200.acf": "\"AppState\"\n{\n\t\"appid\"\t\t\"200\"\n\t\"name\"\t\t\"Broken\"\n\t\"StateFlags\"\t\t\"four\"\n}\n",
+		// App 300 is listed in libraryfolders.vdf but its manifest is gone.
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(steamapps, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	reader, err := NewSteamReader(SteamReaderConfig{CustomSteamPath: steamPath, HomeDir: steamPath})
+	if err != nil {
+		t.Fatal(err)
+	}
+	apps, err := reader.GetAllInstalledApps()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(apps) != 2 {
+		t.Fatalf("GetAllInstalledApps returned %d apps, want 2: %+v", len(apps), apps)
+	}
+
+	if apps[0].AppID != "100" || apps[0].Name != "Valid" || apps[0].ManifestError != "" {
+		t.Errorf("apps[0] = %s %q, ManifestError %q; want the valid app", apps[0].AppID, apps[0].Name, apps[0].ManifestError)
+	}
+	broken := apps[1]
+	if broken.AppID != "200" || !strings.Contains(broken.ManifestError, "StateFlags") {
+		t.Errorf("apps[1] = %s, ManifestError %q; want app 200 with a StateFlags error", broken.AppID, broken.ManifestError)
+	}
+	if broken.SizeOnDisk != 2000 || broken.LibraryPath == "" {
+		t.Errorf("apps[1] SizeOnDisk %d, LibraryPath %q; want the values from libraryfolders.vdf", broken.SizeOnDisk, broken.LibraryPath)
+	}
+
+	if _, err := reader.GetInstalledAppByID("200"); err == nil {
+		t.Error("GetInstalledAppByID(200) succeeded on an invalid manifest")
+	}
+}
+
+// The code in this file was made by an LLM, use in production is highly discouraged as unexpected results may occur. The code in this file is not vetted for stability or edge cases.
@@ -0,0 +1,161 @@
+package steamutils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"unicode/utf16"
+
+	"github.com/iancoleman/orderedmap"
+)
+
+// Binary KeyValues type tags.
+const (
+	binaryVDFMap     = 0x00
+	binaryVDFString  = 0x01
+	binaryVDFInt32   = 0x02
+	binaryVDFFloat32 = 0x03
+	binaryVDFPointer = 0x04
+	binaryVDFWString = 0x05
+	binaryVDFColor   = 0x06
+	binaryVDFUint64  = 0x07
+	binaryVDFEnd     = 0x08
+	binaryVDFInt64   = 0x0A
+	binaryVDFEndAlt  = 0x0B
+)
+
+// UnmarshalBinary parses binary KeyValues data, as found in the .bin files of
+// appcache, into the same shape Unmarshal returns: nested blocks are ordered
+// maps and every other value is a string. Integers are formatted in decimal,
+// floats in the shortest form that round-trips, and colors as "r g b a".
+//
+// WARNING: This function was generated by an LLM. Thoroughly test before production use.
+func UnmarshalBinary(data []byte) (*orderedmap.OrderedMap, error) {
+	d := binaryVDFDecoder{data: data}
+	m, err := d.block(0)
+	if err != nil {
+		return nil, fmt.Errorf("binary VDF at offset %d: %w", d.pos, err)
+	}
+	return m, nil
+}
+
+// maxBinaryVDFDepth bounds nesting so malformed input cannot exhaust the stack.
+const maxBinaryVDFDepth = 64
+
+// binaryVDFDecoder reads binary KeyValues from data.
+type binaryVDFDecoder struct {
+	data []byte
+	pos  int
+}
+
+// block reads entries up to an end tag, or to the end of the data at the top
+// level.
+func (d *binaryVDFDecoder) block(depth int) (*orderedmap.OrderedMap, error) {
+	if depth > maxBinaryVDFDepth {
+		return nil, errors.New("blocks nested too deeply")
+	}
+
+	m := orderedmap.New()
+	for {
+		if d.pos >= len(d.data) {
+			if depth == 0 {
+				return m, nil
+			}
+			return nil, errors.New("unexpected end of data")
+		}
+
+		tag := d.data[d.pos]
+		d.pos++
+		if tag == binaryVDFEnd || tag == binaryVDFEndAlt {
+			return m, nil
+		}
+
+		key, err := d.cString()
+		if err != nil {
+			return nil, err
+		}
+
+		var value interface{}
+		switch tag {
+		case binaryVDFMap:
+			value, err = d.block(depth + 1)
+		case binaryVDFString:
+			value, err = d.cString()
+		case binaryVDFWString:
+			value, err = d.wString()
+		case binaryVDFInt32, binaryVDFPointer:
+			var b []byte
+			if b, err = d.next(4); err == nil {
+				value = strconv.FormatInt(int64(int32(binary.LittleEndian.Uint32(b))), 10)
+			}
+		case binaryVDFFloat32:
+			var b []byte
+			if b, err = d.next(4); err == nil {
+				value = strconv.FormatFloat(float64(math.Float32frombits(binary.LittleEndian.Uint32(b))), 'g', -1, 32)
+			}
+		case binaryVDFColor:
+			var b []byte
+			if b, err = d.next(4); err == nil {
+				value = fmt.Sprintf("%d %d %d %d", b[0], b[1], b[2], b[3])
+			}
+		case binaryVDFUint64:
+			var b []byte
+			if b, err = d.next(8); err == nil {
+				value = strconv.FormatUint(binary.LittleEndian.Uint64(b), 10)
+			}
+		case binaryVDFInt64:
+			var b []byte
+			if b, err = d.next(8); err == nil {
+				value = strconv.FormatInt(int64(binary.LittleEndian.Uint64(b)), 10)
+			}
+		default:
+			return nil, fmt.Errorf("unknown type 0x%02x for key %q", tag, key)
+		}
+		if err != nil {
+			return nil, err
+		}
+		m.Set(key, value)
+	}
+}
+
+// next returns the next n bytes.
+func (d *binaryVDFDecoder) next(n int) ([]byte, error) {
+	if len(d.data)-d.pos < n {
+		return nil, errors.New("unexpected end of data")
+	}
+	b := d.data[d.pos : d.pos+n]
+	d.pos += n
+	return b, nil
+}
+
+// cString reads a NUL-terminated UTF-8 string.
+func (d *binaryVDFDecoder) cString() (string, error) {
+	end := bytes.IndexByte(d.data[d.pos:], 0)
+	if end < 0 {
+		return "", errors.New("unterminated string")
+	}
+	s := string(d.data[d.pos : d.pos+end])
+	d.pos += end + 1
+	return s, nil
+}
+
+// wString reads a NUL-terminated UTF-16LE string.
+func (d *binaryVDFDecoder) wString() (string, error) {
+	var units []uint16
+	for {
+		b, err := d.next(2)
+		if err != nil {
+			return "", errors.New("unterminated wide string")
+		}
+		unit := binary.LittleEndian.Uint16(b)
+		if unit == 0 {
+			return string(utf16.Decode(units)), nil
+		}
+		units = append(units, unit)
+	}
+}
+
+// The code in this file was made by an LLM, use in production is highly discouraged as unexpected results may occur. The code in this file is not vetted for stability or edge cases.
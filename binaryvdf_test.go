@@ -0,0 +1,138 @@
+package steamutils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/iancoleman/orderedmap"
+)
+
+// binaryVDFEntry returns one binary KeyValues entry with a raw value.
+func binaryVDFEntry(tag byte, key string, value []byte) []byte {
+	b := append([]byte{tag}, key...)
+	b = append(b, 0)
+	return append(b, value...)
+}
+
+// binaryVDFMapEnd returns a block entry holding entries, closed with end.
+func binaryVDFMapEnd(key string, end byte, entries ...[]byte) []byte {
+	b := binaryVDFEntry(binaryVDFMap, key, nil)
+	for _, entry := range entries {
+		b = append(b, entry...)
+	}
+	return append(b, end)
+}
+
+// binaryVDFBlock returns a block entry closed with the usual end tag.
+func binaryVDFBlock(key string, entries ...[]byte) []byte {
+	return binaryVDFMapEnd(key, binaryVDFEnd, entries...)
+}
+
+// binaryVDFStr returns a string entry.
+func binaryVDFStr(key, value string) []byte {
+	return binaryVDFEntry(binaryVDFString, key, append([]byte(value), 0))
+}
+
+// binaryVDFInt returns an int32 entry.
+func binaryVDFInt(key string, value int32) []byte {
+	return binaryVDFEntry(binaryVDFInt32, key, binary.LittleEndian.AppendUint32(nil, uint32(value)))
+}
+
+func TestUnmarshalBinary(t *testing.T) {
+	var wide []byte
+	for _, unit := range utf16.Encode([]rune("Grüße 🎮")) {
+		wide = binary.LittleEndian.AppendUint16(wide, unit)
+	}
+	wide = append(wide, 0, 0)
+
+	data := binaryVDFBlock("root",
+		binaryVDFStr("name", "Test"),
+		binaryVDFInt("negative", -5),
+		binaryVDFEntry(binaryVDFFloat32, "float", binary.LittleEndian.AppendUint32(nil, 0x3FC00000)),
+		binaryVDFEntry(binaryVDFPointer, "pointer", binary.LittleEndian.AppendUint32(nil, 7)),
+		binaryVDFEntry(binaryVDFWString, "wide", wide),
+		binaryVDFEntry(binaryVDFColor, "color", []byte{1, 2, 3, 255}),
+		binaryVDFEntry(binaryVDFUint64, "uint64", binary.LittleEndian.AppendUint64(nil, 18446744073709551615)),
+		binaryVDFEntry(binaryVDFInt64, "int64", binary.LittleEndian.AppendUint64(nil, 0xFFFFFFFFFFFFFFFE)),
+		binaryVDFMapEnd("alt", binaryVDFEndAlt, binaryVDFStr("inner", "x")),
+		binaryVDFBlock("empty"),
+	)
+
+	m, err := UnmarshalBinary(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	root, _ := m.Get("root")
+	block, ok := root.(*orderedmap.OrderedMap)
+	if !ok {
+		t.Fatalf("root = %#v, want a block", root)
+	}
+
+	wantKeys := []string{"name", "negative", "float", "pointer", "wide", "color", "uint64", "int64", "alt", "empty"}
+	if keys := block.Keys(); len(keys) != len(wantKeys) {
+		t.Fatalf("keys = %v, want %v", keys, wantKeys)
+	} else {
+		for i := range keys {
+			if keys[i] != wantKeys[i] {
+				t.Fatalf("keys = %v, want %v", keys, wantKeys)
+			}
+		}
+	}
+
+	for key, want := range map[string]string{
+		"name":     "Test",
+		"negative": "-5",
+		"float":    "1.5",
+		"pointer":  "7",
+		"wide":     "Grüße 🎮",
+		"color":    "1 2 3 255",
+		"uint64":   "18446744073709551615",
+		"int64":    "-2",
+	} {
+		if got := vdfString(block, key); got != want {
+			t.Errorf("%s = %q, want %q", key, got, want)
+		}
+	}
+	if got := vdfString(block, "alt", "inner"); got != "x" {
+		t.Errorf("block closed by 0x0B: inner = %q, want x", got)
+	}
+	if empty, _ := block.Get("empty"); empty == nil || len(empty.(*orderedmap.OrderedMap).Keys()) != 0 {
+		t.Errorf("empty = %#v, want an empty block", empty)
+	}
+
+	// Steam ends its files with a second end tag after the top-level block.
+	if _, err := UnmarshalBinary(append(data, binaryVDFEnd)); err != nil {
+		t.Errorf("trailing end tag: %v", err)
+	}
+}
+
+func TestUnmarshalBinaryMalformed(t *testing.T) {
+	data := binaryVDFBlock("root",
+		binaryVDFStr("name", "Test"),
+		binaryVDFEntry(binaryVDFWString, "wide", []byte{'a', 0, 0, 0}),
+		binaryVDFEntry(binaryVDFUint64, "uint64", make([]byte, 8)),
+		binaryVDFBlock("nested", binaryVDFInt("n", 1)),
+	)
+
+	// Every entry is inside the root block, so any cut leaves it unclosed.
+	for n := 1; n < len(data); n++ {
+		if _, err := UnmarshalBinary(data[:n]); err == nil {
+			t.Errorf("decoding the first %d bytes succeeded", n)
+		}
+	}
+
+	if _, err := UnmarshalBinary(binaryVDFEntry(0x09, "bad", nil)); err == nil {
+		t.Error("decoding an unknown type succeeded")
+	}
+
+	deep := bytes.Repeat(binaryVDFEntry(binaryVDFMap, "a", nil), maxBinaryVDFDepth+2)
+	deep = append(deep, bytes.Repeat([]byte{binaryVDFEnd}, maxBinaryVDFDepth+2)...)
+	if _, err := UnmarshalBinary(deep); err == nil {
+		t.Error("decoding blocks nested too deeply succeeded")
+	}
+}
+
+// The code in this file was made by an LLM, use in production is highly discouraged as unexpected results may occur. The code in this file is not vetted for stability or edge cases.
@@ -0,0 +1,398 @@
+package steamutils
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/iancoleman/orderedmap"
+)
+
+// RemoteCacheEntry is one file Steam Cloud tracks for an app, as recorded in
+// userdata/<accountid>/<appid>/remotecache.vdf.
+//
+// WARNING: This type was generated by an LLM. Thoroughly test before production use.
+type RemoteCacheEntry struct {
+	// Name is the file's slash-separated path relative to its root.
+	Name string
+
+	// Root is the Auto-Cloud root number; 0 is the remote folder.
+	Root int
+
+	// RootName is the name of Root, such as "WinAppDataRoaming", or "" for 0.
+	RootName string
+
+	// Size is the file size in bytes.
+	Size int64
+
+	// LocalTime is the modification time of the local file when Steam last
+	// looked at it.
+	LocalTime time.Time
+
+	// Time is the file time at the last completed sync.
+	Time time.Time
+
+	// RemoteTime is the time of the copy stored in the cloud.
+	RemoteTime time.Time
+
+	// SHA is the hex-encoded SHA-1 of the contents Steam last synced.
+	SHA string
+
+	// SyncState is Steam's raw syncstate value: 1 when the file is synced,
+	// 2 when a local change waits for upload, 3 when a cloud change waits for
+	// download and 4 for a conflict. Other values are not interpreted.
+	SyncState int
+
+	// PersistState is 0 for files kept in the cloud, 1 for files that were
+	// forgotten and 2 for deleted ones.
+	PersistState int
+
+	// PlatformsToSync is the platformstosync2 bit mask of the platforms the
+	// file syncs to; -1 means all.
+	PlatformsToSync int64
+}
+
+// RemoteCache is the parsed remotecache.vdf of one app and account.
+//
+// WARNING: This type was generated by an LLM. Thoroughly test before production use.
+type RemoteCache struct {
+	AppID        string
+	AccountID    string
+	ChangeNumber int64
+	Entries      []RemoteCacheEntry
+}
+
+// CloudFileState is the sync state of one Steam Cloud file on this machine.
+type CloudFileState string
+
+// Known cloud file states.
+const (
+	// CloudSynced means Steam records the file as synced and the local file
+	// has not changed since.
+	CloudSynced CloudFileState = "synced"
+
+	// CloudPendingUpload means Steam has queued the file for upload, or the
+	// local file changed after Steam last synced it.
+	CloudPendingUpload CloudFileState = "pending-upload"
+
+	// CloudPendingDownload means Steam has queued a newer cloud copy for
+	// download, or the local file is missing.
+	CloudPendingDownload CloudFileState = "pending-download"
+
+	// CloudConflict means Steam recorded a conflict between the local file
+	// and the cloud copy.
+	CloudConflict CloudFileState = "conflict"
+
+	// CloudNotPersisted means the file is no longer kept in the cloud.
+	CloudNotPersisted CloudFileState = "not-persisted"
+
+	// CloudUnknown means Steam's syncstate is not understood and the local
+	// file is unchanged or could not be located, for example because its
+	// root belongs to another OS.
+	CloudUnknown CloudFileState = "unknown"
+)
+
+// CloudFileStatus is a RemoteCacheEntry with the state of its local file.
+//
+// WARNING: This type was generated by an LLM. Thoroughly test before production use.
+type CloudFileStatus struct {
+	RemoteCacheEntry
+
+	// Path is the local file, or "" if it could not be located.
+	Path string
+
+	State CloudFileState
+}
+
+// CloudSyncSummary is the Steam Cloud state of one app for one account.
+//
+// WARNING: This type was generated by an LLM. Thoroughly test before production use.
+type CloudSyncSummary struct {
+	AppID     string
+	AccountID string
+	Files     []CloudFileStatus
+
+	// Counts of Files by State.
+	Synced          int
+	PendingUpload   int
+	PendingDownload int
+	Conflicts       int
+	Unknown         int
+
+	// InSync is true when no file is pending upload, pending download or in
+	// conflict. Files in an unknown state do not count against it.
+	InSync bool
+}
+
+// ReadRemoteCache parses userdata/<accountID>/<appID>/remotecache.vdf.
+//
+// WARNING: This method was generated by an LLM. Thoroughly test before production use.
+func (steamreader *SteamReader) ReadRemoteCache(accountID, appID string) (*RemoteCache, error) {
+	path := filepath.Join(steamreader.steamPath, "userdata", accountID, appID, "remotecache.vdf")
+	cache, err := readRemoteCache(path)
+	if err != nil {
+		return nil, err
+	}
+	cache.AccountID = accountID
+	if cache.AppID == "" {
+		cache.AppID = appID
+	}
+	return cache, nil
+}
+
+// CloudSyncStatus compares the remotecache.vdf of each account with the files
+// on disk and summarizes the Steam Cloud state of appIDs, or of every app with
+// a remotecache.vdf if appIDs is empty. Apps need not be installed, but files
+// under the GameInstall root or in a Proton prefix can only be located for
+// installed apps.
+//
+// Each file's state comes from the syncstate Steam recorded for it. Only a
+// file Steam considers synced, or whose syncstate is not understood, is then
+// compared with the disk, to catch changes made since Steam last looked: it
+// is pending upload if its SHA-1 differs from the one Steam recorded or,
+// without a recorded hash, if its modification time differs from LocalTime.
+//
+// WARNING: This method was generated by an LLM. Thoroughly test before production use.
+func (steamreader *SteamReader) CloudSyncStatus(appIDs ...string) ([]CloudSyncSummary, error) {
+	accounts, err := steamreader.userdataAccounts()
+	if err != nil {
+		return nil, err
+	}
+
+	var summaries []CloudSyncSummary
+	for _, accountID := range accounts {
+		ids := appIDs
+		if len(ids) == 0 {
+			entries, err := os.ReadDir(filepath.Join(steamreader.steamPath, "userdata", accountID))
+			if err != nil {
+				return nil, fmt.Errorf("failed to read userdata: %w", err)
+			}
+			for _, entry := range entries {
+				ids = append(ids, entry.Name())
+			}
+		}
+
+		for _, appID := range ids {
+			cache, err := steamreader.ReadRemoteCache(accountID, appID)
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			summaries = append(summaries, steamreader.summarizeRemoteCache(cache))
+		}
+	}
+	return summaries, nil
+}
+
+// summarizeRemoteCache works out the state of every entry of cache.
+func (steamreader *SteamReader) summarizeRemoteCache(cache *RemoteCache) CloudSyncSummary {
+	app, err := steamreader.GetInstalledAppByID(cache.AppID)
+	if err != nil {
+		app = &InstalledApp{AppID: cache.AppID}
+	}
+
+	summary := CloudSyncSummary{AppID: cache.AppID, AccountID: cache.AccountID}
+	for _, entry := range cache.Entries {
+		status := CloudFileStatus{RemoteCacheEntry: entry}
+
+		var dir string
+		if entry.Root == 0 {
+			dir = filepath.Join(steamreader.steamPath, "userdata", cache.AccountID, cache.AppID, "remote")
+		} else {
+			dir, _ = steamreader.saveRootDir(app, entry.RootName)
+		}
+		if dir != "" && filepath.IsLocal(filepath.FromSlash(entry.Name)) {
+			status.Path = filepath.Join(dir, filepath.FromSlash(entry.Name))
+		}
+
+		status.State = cloudFileState(entry, status.Path)
+		switch status.State {
+		case CloudSynced:
+			summary.Synced++
+		case CloudPendingUpload:
+			summary.PendingUpload++
+		case CloudPendingDownload:
+			summary.PendingDownload++
+		case CloudConflict:
+			summary.Conflicts++
+		case CloudUnknown:
+			summary.Unknown++
+		}
+		summary.Files = append(summary.Files, status)
+	}
+
+	summary.InSync = summary.PendingUpload == 0 && summary.PendingDownload == 0 && summary.Conflicts == 0
+	return summary
+}
+
+// remotecache.vdf persiststate and syncstate values. Steam does not document
+// them; these are the meanings seen in the files the client writes.
+const (
+	cloudPersisted = 0
+
+	cloudSyncStateSynced          = 1
+	cloudSyncStatePendingUpload   = 2
+	cloudSyncStatePendingDownload = 3
+	cloudSyncStateConflict        = 4
+)
+
+// cloudSyncStates maps the syncstate values of pending work to their state.
+var cloudSyncStates = map[int]CloudFileState{
+	cloudSyncStatePendingUpload:   CloudPendingUpload,
+	cloudSyncStatePendingDownload: CloudPendingDownload,
+	cloudSyncStateConflict:        CloudConflict,
+}
+
+// cloudFileState works out the state of entry from its persiststate and
+// syncstate, checking the local file at path for changes Steam has not seen
+// yet when Steam considers it synced or its syncstate is unknown.
+func cloudFileState(entry RemoteCacheEntry, path string) CloudFileState {
+	if entry.PersistState != cloudPersisted {
+		return CloudNotPersisted
+	}
+	if state, pending := cloudSyncStates[entry.SyncState]; pending {
+		return state
+	}
+
+	// Without Steam's word that the file is synced, an unchanged local file
+	// does not prove anything.
+	unchanged := CloudUnknown
+	if entry.SyncState == cloudSyncStateSynced {
+		unchanged = CloudSynced
+	}
+	if path == "" {
+		return unchanged
+	}
+
+	info, err := os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return CloudPendingDownload
+	}
+	if err != nil {
+		return CloudUnknown
+	}
+
+	var localChanged bool
+	if len(entry.SHA) == sha1.Size*2 {
+		sum, err := sha1File(path)
+		if err != nil {
+			return CloudUnknown
+		}
+		localChanged = !strings.EqualFold(sum, entry.SHA)
+	} else {
+		localChanged = info.ModTime().Unix() != entry.LocalTime.Unix()
+	}
+
+	if localChanged {
+		return CloudPendingUpload
+	}
+	return unchanged
+}
+
+// readRemoteCache parses the remotecache.vdf at path.
+func readRemoteCache(path string) (*RemoteCache, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	vdfMap, err := Unmarshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	cache := &RemoteCache{}
+	for _, appKey := range vdfMap.Keys() {
+		appVal, _ := vdfMap.Get(appKey)
+		appBlock, ok := appVal.(*orderedmap.OrderedMap)
+		if !ok {
+			continue
+		}
+
+		cache.AppID = appKey
+		if cache.ChangeNumber, err = manifestInt(appBlock, "ChangeNumber"); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+
+		for _, name := range appBlock.Keys() {
+			entryVal, _ := appBlock.Get(name)
+			entryMap, ok := entryVal.(*orderedmap.OrderedMap)
+			if !ok {
+				continue
+			}
+
+			entry, err := remoteCacheEntry(name, entryMap)
+			if err != nil {
+				return nil, fmt.Errorf("%s: file %s: %w", path, name, err)
+			}
+			cache.Entries = append(cache.Entries, entry)
+		}
+	}
+	return cache, nil
+}
+
+// remoteCacheEntry parses the block of one file in remotecache.vdf.
+func remoteCacheEntry(name string, m *orderedmap.OrderedMap) (RemoteCacheEntry, error) {
+	entry := RemoteCacheEntry{Name: name, SHA: vdfString(m, "sha")}
+
+	var err error
+	var root, syncState, persistState int64
+	for _, field := range []struct {
+		key    string
+		target *int64
+	}{
+		{"root", &root},
+		{"size", &entry.Size},
+		{"syncstate", &syncState},
+		{"persiststate", &persistState},
+		{"platformstosync2", &entry.PlatformsToSync},
+	} {
+		if *field.target, err = manifestInt(m, field.key); err != nil {
+			return RemoteCacheEntry{}, err
+		}
+	}
+	entry.Root = int(root)
+	entry.RootName = remoteStorageRootName(entry.Root)
+	entry.SyncState = int(syncState)
+	entry.PersistState = int(persistState)
+
+	for _, field := range []struct {
+		key    string
+		target *time.Time
+	}{
+		{"localtime", &entry.LocalTime},
+		{"time", &entry.Time},
+		{"remotetime", &entry.RemoteTime},
+	} {
+		if *field.target, err = manifestTime(m, field.key); err != nil {
+			return RemoteCacheEntry{}, err
+		}
+	}
+	return entry, nil
+}
+
+// sha1File returns the hex-encoded SHA-1 of the file at path.
+func sha1File(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha1.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// The code in this file was made by an LLM, use in production is highly discouraged as unexpected results may occur. The code in this file is not vetted for stability or edge cases.
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/bomkz/steamutils"
+)
+
+func runArt(args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: expected a subcommand: list, set, remove or shortcut-id", errUsage)
+	}
+
+	switch args[0] {
+	case "list":
+		return runArtList(args[1:], stdout)
+	case "set":
+		return runArtSet(args[1:], stdout)
+	case "remove":
+		return runArtRemove(args[1:], stdout)
+	case "shortcut-id":
+		return runArtShortcutID(args[1:], stdout)
+	default:
+		return fmt.Errorf("%w: unknown art subcommand %q", errUsage, args[0])
+	}
+}
+
+// runArtList prints every library image found for an app, preferred first.
+func runArtList(args []string, stdout io.Writer) error {
+	fs := newFlagSet("art list", "<appid>")
+	format := formatFlag(fs)
+	account := fs.String("account", "", "include the custom art of account `id`")
+	if err := parseFlags(fs, args, 1, 1); err != nil {
+		return err
+	}
+
+	reader, err := newReader()
+	if err != nil {
+		return err
+	}
+
+	artwork, err := reader.GetArtwork(fs.Arg(0), *account)
+	if err != nil {
+		return err
+	}
+
+	t := table{header: []string{"TYPE", "SOURCE", "FORMAT", "SIZE", "PATH"}}
+	for _, art := range artwork {
+		size := ""
+		if art.Width != 0 && art.Height != 0 {
+			size = strconv.Itoa(art.Width) + "x" + strconv.Itoa(art.Height)
+		}
+		t.rows = append(t.rows, []string{string(art.Type), string(art.Source), art.Format, size, art.Path})
+	}
+	return writeOutput(stdout, *format, t, artwork)
+}
+
+// runArtSet installs an image as an account's custom art for an app.
+func runArtSet(args []string, stdout io.Writer) error {
+	fs := newFlagSet("art set", "<appid> <type> <image>")
+	account := fs.String("account", "", "account `id` to set the art for (required)")
+	if err := parseFlags(fs, args, 3, 3); err != nil {
+		return err
+	}
+	artType, err := parseArtworkType(fs.Arg(1))
+	if err != nil {
+		return err
+	}
+	if *account == "" {
+		return fmt.Errorf("%w: --account is required", errUsage)
+	}
+
+	reader, err := newReader()
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(fs.Arg(2))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	art, err := reader.SetCustomArtwork(*account, fs.Arg(0), artType, f)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "installed %dx%d %s as %s\n", art.Width, art.Height, art.Format, art.Path)
+	return nil
+}
+
+// runArtRemove deletes an account's custom art for an app.
+func runArtRemove(args []string, stdout io.Writer) error {
+	fs := newFlagSet("art remove", "<appid> <type>")
+	account := fs.String("account", "", "account `id` to remove the art of (required)")
+	if err := parseFlags(fs, args, 2, 2); err != nil {
+		return err
+	}
+	artType, err := parseArtworkType(fs.Arg(1))
+	if err != nil {
+		return err
+	}
+	if *account == "" {
+		return fmt.Errorf("%w: --account is required", errUsage)
+	}
+
+	reader, err := newReader()
+	if err != nil {
+		return err
+	}
+	return reader.RemoveCustomArtwork(*account, fs.Arg(0), artType)
+}
+
+// runArtShortcutID prints the artwork ID of a non-Steam game.
+func runArtShortcutID(args []string, stdout io.Writer) error {
+	fs := newFlagSet("art shortcut-id", "<exe> <name>")
+	if err := parseFlags(fs, args, 2, 2); err != nil {
+		return err
+	}
+	fmt.Fprintln(stdout, steamutils.ShortcutAppID(fs.Arg(0), fs.Arg(1)))
+	return nil
+}
+
+// parseArtworkType validates an artwork type argument.
+func parseArtworkType(value string) (steamutils.ArtworkType, error) {
+	for _, artType := range steamutils.ArtworkTypes {
+		if string(artType) == value {
+			return artType, nil
+		}
+	}
+	return "", fmt.Errorf("%w: unknown artwork type %q (want capsule, header, hero, logo or icon)", errUsage, value)
+}
+
+// The code in this file was made by an LLM, use in production is highly discouraged as unexpected results may occur. The code in this file is not vetted for stability or edge cases.
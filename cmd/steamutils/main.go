@@ -0,0 +1,255 @@
+// Command steamutils inspects Steam installations from the command line.
+//
+// Usage:
+//
+//	steamutils [--steam-path DIR] <command> [flags] [args]
+//
+// Commands:
+//
+//	path        print the Steam installation and libraryfolders.vdf paths
+//	libraries   list library folders
+//	apps        list installed apps, with filters
+//	app <id>    show the full manifest detail of one app
+//	vdf get     print a value or block from a VDF file
+//	vdf set     set a value in a VDF file
+//	vdf restore roll a VDF file back to a backup
+//	users       list accounts that have logged in to Steam
+//	serve       serve a read-only JSON API over HTTP
+//	metrics     print Prometheus metrics
+//	saves       list, check, back up or restore game saves
+//	screenshots list or export screenshots grouped by game
+//	art         list, set or remove library artwork
+//	stats       show cached achievement progress
+//
+// CAUTION: This tool was generated by an LLM. It has not been thoroughly tested or verified
+// for production use.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/bomkz/steamutils"
+)
+
+// errUsage marks errors caused by invalid command-line arguments.
+var errUsage = errors.New("usage error")
+
+// command is a steamutils subcommand.
+type command struct {
+	name    string
+	summary string
+	run     func(args []string, stdout io.Writer) error
+}
+
+// globalFlags holds the flags accepted before the subcommand name.
+var globalFlags struct {
+	steamPath      string
+	libraryVdfPath string
+	pathMappings   pathMappingsFlag
+	wsl            bool
+}
+
+// pathMappingsFlag collects repeated --path-map FROM=TO flags.
+type pathMappingsFlag []steamutils.PathMapping
+
+func (mappings *pathMappingsFlag) String() string {
+	var parts []string
+	for _, mapping := range *mappings {
+		parts = append(parts, mapping.From+"="+mapping.To)
+	}
+	return strings.Join(parts, ",")
+}
+
+func (mappings *pathMappingsFlag) Set(value string) error {
+	from, to, found := strings.Cut(value, "=")
+	if !found || from == "" || to == "" {
+		return fmt.Errorf("expected FROM=TO, got %q", value)
+	}
+	*mappings = append(*mappings, steamutils.PathMapping{From: from, To: to})
+	return nil
+}
+
+func commands() []command {
+	return []command{
+		{"path", "print the Steam installation and libraryfolders.vdf paths", runPath},
+		{"libraries", "list library folders", runLibraries},
+		{"apps", "list installed apps", runApps},
+		{"app", "show the full manifest detail of one app", runApp},
+		{"vdf", "read or modify a VDF file (get, set, restore)", runVdf},
+		{"users", "list accounts that have logged in to Steam", runUsers},
+		{"serve", "serve a read-only JSON API over HTTP", runServe},
+		{"metrics", "print Prometheus metrics", runMetrics},
+		{"saves", "list, check, back up or restore game saves (list, status, backup, restore)", runSaves},
+		{"screenshots", "list or export screenshots grouped by game (list, export)", runScreenshots},
+		{"art", "list, set or remove library artwork (list, set, remove, shortcut-id)", runArt},
+		{"stats", "show cached achievement progress (list, show)", runStats},
+	}
+}
+
+func main() {
+	flag.StringVar(&globalFlags.steamPath, "steam-path", "", "Steam installation `directory` (overrides detection, like SteamReaderConfig.CustomSteamPath)")
+	flag.StringVar(&globalFlags.libraryVdfPath, "library-vdf", "", "libraryfolders.vdf `file` (overrides detection, like SteamReaderConfig.CustomLibraryVdfPath)")
+	flag.Var(&globalFlags.pathMappings, "path-map", "map library paths recorded for another OS, as `FROM=TO` (e.g. 'D:\\=/mnt/d'); repeatable")
+	flag.BoolVar(&globalFlags.wsl, "wsl", false, "map Windows drive letters to their WSL mounts under /mnt")
+	flag.Usage = usage
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+
+	name, args := flag.Arg(0), flag.Args()[1:]
+	for _, cmd := range commands() {
+		if cmd.name != name {
+			continue
+		}
+
+		err := cmd.run(args, os.Stdout)
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(0)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "steamutils %s: %v\n", name, err)
+			if errors.Is(err, errUsage) {
+				os.Exit(2)
+			}
+			os.Exit(1)
+		}
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "steamutils: unknown command %q\n", name)
+	usage()
+	os.Exit(2)
+}
+
+func usage() {
+	out := flag.CommandLine.Output()
+	fmt.Fprintf(out, "Usage: steamutils [flags] <command> [args]\n\nCommands:\n")
+	for _, cmd := range commands() {
+		fmt.Fprintf(out, "  %-10s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintf(out, "\nFlags:\n")
+	flag.PrintDefaults()
+}
+
+// newReader creates a SteamReader honouring the global path overrides.
+func newReader() (*steamutils.SteamReader, error) {
+	mappings := []steamutils.PathMapping(globalFlags.pathMappings)
+	if globalFlags.wsl {
+		mappings = append(mappings, steamutils.WSLPathMappings("")...)
+	}
+
+	reader, err := steamutils.NewSteamReader(steamutils.SteamReaderConfig{
+		CustomSteamPath:      globalFlags.steamPath,
+		CustomLibraryVdfPath: globalFlags.libraryVdfPath,
+		PathMappings:         mappings,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &reader, nil
+}
+
+// newFlagSet returns a FlagSet for a subcommand whose errors are returned
+// rather than exiting the process.
+func newFlagSet(name, argsUsage string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: steamutils %s [flags] %s\n", name, argsUsage)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseFlags parses a subcommand's flags and checks its positional argument count.
+func parseFlags(fs *flag.FlagSet, args []string, minArgs, maxArgs int) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+	if fs.NArg() < minArgs || (maxArgs >= 0 && fs.NArg() > maxArgs) {
+		fs.Usage()
+		return fmt.Errorf("%w: wrong number of arguments", errUsage)
+	}
+	if f := fs.Lookup("format"); f != nil {
+		switch f.Value.String() {
+		case formatTable, formatJSON, formatCSV, "text":
+		default:
+			return fmt.Errorf("%w: unknown format %q", errUsage, f.Value.String())
+		}
+	}
+	return nil
+}
+
+func runPath(args []string, stdout io.Writer) error {
+	fs := newFlagSet("path", "")
+	if err := parseFlags(fs, args, 0, 0); err != nil {
+		return err
+	}
+
+	reader, err := newReader()
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "steam\t%s\n", reader.GetSteamPath())
+	fmt.Fprintf(stdout, "libraryfolders\t%s\n", reader.GetLibraryVdfPath())
+	return nil
+}
+
+// runMetrics prints the Prometheus metrics once, for example for the
+// node_exporter textfile collector.
+func runMetrics(args []string, stdout io.Writer) error {
+	fs := newFlagSet("metrics", "")
+	if err := parseFlags(fs, args, 0, 0); err != nil {
+		return err
+	}
+
+	reader, err := newReader()
+	if err != nil {
+		return err
+	}
+
+	return reader.WriteMetrics(stdout)
+}
+
+func runUsers(args []string, stdout io.Writer) error {
+	fs := newFlagSet("users", "")
+	format := formatFlag(fs)
+	if err := parseFlags(fs, args, 0, 0); err != nil {
+		return err
+	}
+
+	reader, err := newReader()
+	if err != nil {
+		return err
+	}
+
+	users, err := reader.GetLoginUsers()
+	if err != nil {
+		return err
+	}
+
+	t := table{header: []string{"STEAMID", "ACCOUNT", "PERSONA", "MOSTRECENT", "LASTLOGIN"}}
+	for _, user := range users {
+		t.rows = append(t.rows, []string{
+			user.SteamID,
+			user.AccountName,
+			user.PersonaName,
+			fmt.Sprint(user.MostRecent),
+			formatUnix(user.Timestamp),
+		})
+	}
+	return writeOutput(stdout, *format, t, users)
+}
+
+// The code in this file was made by an LLM, use in production is highly discouraged as unexpected results may occur. The code in this file is not vetted for stability or edge cases.
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Output formats accepted by --format.
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatCSV   = "csv"
+)
+
+// table is tabular command output.
+type table struct {
+	header []string
+	rows   [][]string
+}
+
+// formatFlag registers the --format flag on fs.
+func formatFlag(fs *flag.FlagSet) *string {
+	return fs.String("format", formatTable, "output `format`: table, json or csv")
+}
+
+// writeOutput writes t as an aligned table or CSV, or value as indented JSON.
+func writeOutput(w io.Writer, format string, t table, value interface{}) error {
+	switch format {
+	case formatTable:
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, strings.Join(t.header, "\t"))
+		for _, row := range t.rows {
+			fmt.Fprintln(tw, strings.Join(row, "\t"))
+		}
+		return tw.Flush()
+
+	case formatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(t.header); err != nil {
+			return err
+		}
+		if err := cw.WriteAll(t.rows); err != nil {
+			return err
+		}
+		return cw.Error()
+
+	case formatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(value)
+
+	default:
+		return fmt.Errorf("%w: unknown format %q", errUsage, format)
+	}
+}
+
+// formatBytes renders a byte count with a binary unit suffix.
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// parseBytes parses a size such as "1500", "20M" or "1.5GiB" into bytes.
+func parseBytes(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	number := strings.TrimRight(s, "BbIi")
+	multiplier := float64(1)
+	if number != "" {
+		if i := strings.IndexByte("KMGTPE", strings.ToUpper(number[len(number)-1:])[0]); i >= 0 {
+			number = number[:len(number)-1]
+			for ; i >= 0; i-- {
+				multiplier *= 1024
+			}
+		}
+	}
+
+	value, err := strconv.ParseFloat(strings.TrimSpace(number), 64)
+	if err != nil || value < 0 {
+		return 0, fmt.Errorf("%w: invalid size %q", errUsage, s)
+	}
+	return int64(value * multiplier), nil
+}
+
+// formatTime renders t, or "never" for the zero time.
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+	return t.Format(time.RFC3339)
+}
+
+// formatUnix renders a Unix timestamp, or "never" for zero.
+func formatUnix(unix int64) string {
+	if unix == 0 {
+		return "never"
+	}
+	return formatTime(time.Unix(unix, 0))
+}
+
+// The code in this file was made by an LLM, use in production is highly discouraged as unexpected results may occur. The code in this file is not vetted for stability or edge cases.
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/bomkz/steamutils"
+)
+
+func runSaves(args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: expected a subcommand: list, status, backup or restore", errUsage)
+	}
+
+	switch args[0] {
+	case "list":
+		return runSavesList(args[1:], stdout)
+	case "status":
+		return runSavesStatus(args[1:], stdout)
+	case "backup":
+		return runSavesBackup(args[1:], stdout)
+	case "restore":
+		return runSavesRestore(args[1:], stdout)
+	default:
+		return fmt.Errorf("%w: unknown saves subcommand %q", errUsage, args[0])
+	}
+}
+
+// runSavesList prints every save file found for an app with its location.
+func runSavesList(args []string, stdout io.Writer) error {
+	fs := newFlagSet("saves list", "<appid>")
+	format := formatFlag(fs)
+	if err := parseFlags(fs, args, 1, 1); err != nil {
+		return err
+	}
+
+	reader, err := newReader()
+	if err != nil {
+		return err
+	}
+
+	locations, err := reader.FindSaves(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	t := table{header: []string{"KIND", "ACCOUNT", "ROOT", "FILE", "PATH"}}
+	for _, location := range locations {
+		for _, file := range location.Files {
+			t.rows = append(t.rows, []string{
+				string(location.Kind),
+				location.AccountID,
+				location.Root,
+				file,
+				location.Path,
+			})
+		}
+	}
+	return writeOutput(stdout, *format, t, locations)
+}
+
+// runSavesStatus prints the Steam Cloud sync state per app and account; with
+// --files, every tracked file is listed instead.
+func runSavesStatus(args []string, stdout io.Writer) error {
+	fs := newFlagSet("saves status", "[appid...]")
+	format := formatFlag(fs)
+	files := fs.Bool("files", false, "list every tracked file with its state")
+	if err := parseFlags(fs, args, 0, -1); err != nil {
+		return err
+	}
+
+	reader, err := newReader()
+	if err != nil {
+		return err
+	}
+
+	summaries, err := reader.CloudSyncStatus(fs.Args()...)
+	if err != nil {
+		return err
+	}
+
+	if *files {
+		t := table{header: []string{"ACCOUNT", "APPID", "ROOT", "FILE", "STATE"}}
+		for _, summary := range summaries {
+			for _, file := range summary.Files {
+				t.rows = append(t.rows, []string{summary.AccountID, summary.AppID, file.RootName, file.Name, string(file.State)})
+			}
+		}
+		return writeOutput(stdout, *format, t, summaries)
+	}
+
+	t := table{header: []string{"ACCOUNT", "APPID", "SYNCED", "UPLOAD", "DOWNLOAD", "CONFLICT", "UNKNOWN", "INSYNC"}}
+	for _, summary := range summaries {
+		t.rows = append(t.rows, []string{
+			summary.AccountID,
+			summary.AppID,
+			strconv.Itoa(summary.Synced),
+			strconv.Itoa(summary.PendingUpload),
+			strconv.Itoa(summary.PendingDownload),
+			strconv.Itoa(summary.Conflicts),
+			strconv.Itoa(summary.Unknown),
+			strconv.FormatBool(summary.InSync),
+		})
+	}
+	return writeOutput(stdout, *format, t, summaries)
+}
+
+// runSavesBackup writes the saves of the given apps to a tar.gz archive.
+func runSavesBackup(args []string, stdout io.Writer) error {
+	fs := newFlagSet("saves backup", "<archive.tar.gz> <appid>...")
+	if err := parseFlags(fs, args, 2, -1); err != nil {
+		return err
+	}
+
+	reader, err := newReader()
+	if err != nil {
+		return err
+	}
+
+	path := fs.Arg(0)
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	manifest, err := reader.BackupSaves(f, fs.Args()[1:]...)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		return err
+	}
+
+	fmt.Fprintf(stdout, "backed up %d files to %s\n", len(manifest.Files), path)
+	return nil
+}
+
+// runSavesRestore restores a saves archive written by saves backup.
+func runSavesRestore(args []string, stdout io.Writer) error {
+	fs := newFlagSet("saves restore", "<archive.tar.gz>")
+	account := fs.String("account", "", "restore every account's saves into account `id`")
+	dryRun := fs.Bool("dry-run", false, "verify the archive without writing any file")
+	if err := parseFlags(fs, args, 1, 1); err != nil {
+		return err
+	}
+	if *account != "" {
+		if _, err := strconv.ParseUint(*account, 10, 32); err != nil {
+			return fmt.Errorf("%w: invalid account ID %q", errUsage, *account)
+		}
+	}
+
+	reader, err := newReader()
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	manifest, err := reader.RestoreSaves(f, steamutils.RestoreSavesOptions{AccountID: *account, DryRun: *dryRun})
+	if err != nil {
+		return err
+	}
+
+	verb := "restored"
+	if *dryRun {
+		verb = "verified"
+	}
+	fmt.Fprintf(stdout, "%s %d files from %s\n", verb, len(manifest.Files), fs.Arg(0))
+	return nil
+}
+
+// The code in this file was made by an LLM, use in production is highly discouraged as unexpected results may occur. The code in this file is not vetted for stability or edge cases.
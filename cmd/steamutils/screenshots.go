@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/bomkz/steamutils"
+)
+
+func runScreenshots(args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: expected a subcommand: list or export", errUsage)
+	}
+
+	switch args[0] {
+	case "list":
+		return runScreenshotsList(args[1:], stdout)
+	case "export":
+		return runScreenshotsExport(args[1:], stdout)
+	default:
+		return fmt.Errorf("%w: unknown screenshots subcommand %q", errUsage, args[0])
+	}
+}
+
+// runScreenshotsList prints every screenshot, optionally of one account or app.
+func runScreenshotsList(args []string, stdout io.Writer) error {
+	fs := newFlagSet("screenshots list", "[appid]")
+	format := formatFlag(fs)
+	account := fs.String("account", "", "only list the screenshots of account `id`")
+	if err := parseFlags(fs, args, 0, 1); err != nil {
+		return err
+	}
+
+	screenshots, err := readScreenshots(*account, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	t := table{header: []string{"ACCOUNT", "APPID", "NAME", "CREATED", "SIZE", "CAPTION", "PATH"}}
+	for _, screenshot := range screenshots {
+		size := ""
+		if screenshot.Width != 0 && screenshot.Height != 0 {
+			size = strconv.Itoa(screenshot.Width) + "x" + strconv.Itoa(screenshot.Height)
+		}
+		t.rows = append(t.rows, []string{
+			screenshot.AccountID,
+			screenshot.AppID,
+			screenshot.AppName,
+			formatTime(screenshot.Created),
+			size,
+			screenshot.Caption,
+			screenshot.Path,
+		})
+	}
+	return writeOutput(stdout, *format, t, screenshots)
+}
+
+// runScreenshotsExport copies screenshots into a folder per game.
+func runScreenshotsExport(args []string, stdout io.Writer) error {
+	fs := newFlagSet("screenshots export", "<dir> [appid]")
+	format := formatFlag(fs)
+	account := fs.String("account", "", "only export the screenshots of account `id`")
+	thumbnails := fs.Bool("thumbnails", false, "also export thumbnails")
+	if err := parseFlags(fs, args, 1, 2); err != nil {
+		return err
+	}
+
+	screenshots, err := readScreenshots(*account, fs.Arg(1))
+	if err != nil {
+		return err
+	}
+
+	groups, err := steamutils.ExportScreenshots(screenshots, fs.Arg(0), *thumbnails)
+	if err != nil {
+		return err
+	}
+
+	t := table{header: []string{"APPID", "NAME", "SCREENSHOTS"}}
+	for _, group := range groups {
+		t.rows = append(t.rows, []string{group.AppID, group.AppName, strconv.Itoa(len(group.Screenshots))})
+	}
+	return writeOutput(stdout, *format, t, groups)
+}
+
+// readScreenshots lists the screenshots of account, or of every account if it
+// is empty, keeping only those of appID if it is set.
+func readScreenshots(account, appID string) ([]steamutils.Screenshot, error) {
+	reader, err := newReader()
+	if err != nil {
+		return nil, err
+	}
+
+	screenshots, err := reader.GetScreenshots(account)
+	if err != nil || appID == "" {
+		return screenshots, err
+	}
+
+	var filtered []steamutils.Screenshot
+	for _, screenshot := range screenshots {
+		if screenshot.AppID == appID {
+			filtered = append(filtered, screenshot)
+		}
+	}
+	return filtered, nil
+}
+
+// The code in this file was made by an LLM, use in production is highly discouraged as unexpected results may occur. The code in this file is not vetted for stability or edge cases.
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/bomkz/steamutils"
+)
+
+// runServe serves the read-only JSON API on a TCP address or a Unix socket
+// until interrupted.
+func runServe(args []string, stdout io.Writer) error {
+	fs := newFlagSet("serve", "")
+	addr := fs.String("addr", "127.0.0.1:8765", "TCP `address` to listen on")
+	socket := fs.String("socket", "", "listen on the Unix socket at `path` instead of --addr")
+	poll := fs.Duration("poll", 2*time.Second, "how often the /events stream checks for changes")
+	if err := parseFlags(fs, args, 0, 0); err != nil {
+		return err
+	}
+
+	reader, err := newReader()
+	if err != nil {
+		return err
+	}
+
+	var listener net.Listener
+	if *socket != "" {
+		// A socket left behind by a previous run would make Listen fail.
+		if info, err := os.Lstat(*socket); err == nil && info.Mode()&os.ModeSocket != 0 {
+			os.Remove(*socket)
+		}
+		listener, err = net.Listen("unix", *socket)
+		if err == nil {
+			defer os.Remove(*socket)
+		}
+	} else {
+		listener, err = net.Listen("tcp", *addr)
+	}
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	server := &http.Server{
+		Handler:           steamutils.NewHTTPHandler(reader, steamutils.HTTPHandlerOptions{PollInterval: *poll}),
+		ReadHeaderTimeout: 10 * time.Second,
+		// Cancelling request contexts on shutdown ends open /events streams.
+		BaseContext: func(net.Listener) context.Context { return ctx },
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Fprintf(stdout, "serving on %s\n", listener.Addr())
+	if err := server.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// The code in this file was made by an LLM, use in production is highly discouraged as unexpected results may occur. The code in this file is not vetted for stability or edge cases.
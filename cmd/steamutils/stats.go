@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+func runStats(args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: expected a subcommand: list or show", errUsage)
+	}
+
+	switch args[0] {
+	case "list":
+		return runStatsList(args[1:], stdout)
+	case "show":
+		return runStatsShow(args[1:], stdout)
+	default:
+		return fmt.Errorf("%w: unknown stats subcommand %q", errUsage, args[0])
+	}
+}
+
+// runStatsList prints the achievement progress of every game with cached
+// stats for an account.
+func runStatsList(args []string, stdout io.Writer) error {
+	fs := newFlagSet("stats list", "<accountid>")
+	format := formatFlag(fs)
+	if err := parseFlags(fs, args, 1, 1); err != nil {
+		return err
+	}
+
+	reader, err := newReader()
+	if err != nil {
+		return err
+	}
+
+	games, err := reader.GetAllUserGameStats(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	t := table{header: []string{"APPID", "NAME", "UNLOCKED", "TOTAL"}}
+	for _, game := range games {
+		t.rows = append(t.rows, []string{game.AppID, game.GameName, strconv.Itoa(game.Unlocked), strconv.Itoa(game.Total)})
+	}
+	return writeOutput(stdout, *format, t, games)
+}
+
+// runStatsShow prints every achievement of one game with its unlock state.
+func runStatsShow(args []string, stdout io.Writer) error {
+	fs := newFlagSet("stats show", "<accountid> <appid>")
+	format := formatFlag(fs)
+	language := fs.String("language", "english", "Steam `language` of achievement names and descriptions")
+	if err := parseFlags(fs, args, 2, 2); err != nil {
+		return err
+	}
+
+	reader, err := newReader()
+	if err != nil {
+		return err
+	}
+
+	stats, err := reader.ReadUserGameStats(fs.Arg(0), fs.Arg(1))
+	if err != nil {
+		return err
+	}
+
+	t := table{header: []string{"ACHIEVEMENT", "NAME", "UNLOCKED", "DESCRIPTION"}}
+	for _, achievement := range stats.Achievements {
+		unlocked := "no"
+		if achievement.Unlocked {
+			unlocked = formatTime(achievement.UnlockTime)
+			if achievement.UnlockTime.IsZero() {
+				unlocked = "yes"
+			}
+		}
+		description := achievement.LocalizedDescription(*language)
+		if achievement.Hidden && !achievement.Unlocked {
+			description = "(hidden)"
+		}
+		t.rows = append(t.rows, []string{achievement.Name, achievement.LocalizedName(*language), unlocked, description})
+	}
+	return writeOutput(stdout, *format, t, stats)
+}
+
+// The code in this file was made by an LLM, use in production is highly discouraged as unexpected results may occur. The code in this file is not vetted for stability or edge cases.
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/bomkz/steamutils"
+	"github.com/iancoleman/orderedmap"
+)
+
+func runVdf(args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: expected a subcommand: get, set or restore", errUsage)
+	}
+
+	switch args[0] {
+	case "get":
+		return runVdfGet(args[1:], stdout)
+	case "set":
+		return runVdfSet(args[1:])
+	case "restore":
+		return runVdfRestore(args[1:])
+	default:
+		return fmt.Errorf("%w: unknown vdf subcommand %q", errUsage, args[0])
+	}
+}
+
+// runVdfGet prints the value at a slash-separated key path, or the whole block
+// in VDF form. Keys are matched case-insensitively, as Steam does.
+func runVdfGet(args []string, stdout io.Writer) error {
+	fs := newFlagSet("vdf get", "<file> [key/path]")
+	if err := parseFlags(fs, args, 1, 2); err != nil {
+		return err
+	}
+
+	vdfMap, err := readVdfFile(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	var value interface{} = vdfMap
+	for _, key := range splitKeyPath(fs.Arg(1)) {
+		block, ok := value.(*orderedmap.OrderedMap)
+		if !ok {
+			return fmt.Errorf("%s is a value, not a block", key)
+		}
+		actual, found := findKey(block, key)
+		if !found {
+			return fmt.Errorf("key %q not found", key)
+		}
+		value, _ = block.Get(actual)
+	}
+
+	switch v := value.(type) {
+	case string:
+		fmt.Fprintln(stdout, v)
+		return nil
+	case *orderedmap.OrderedMap:
+		data, err := steamutils.Marshal(v)
+		if err != nil {
+			return err
+		}
+		_, err = stdout.Write(data)
+		return err
+	default:
+		return fmt.Errorf("unexpected value type %T", value)
+	}
+}
+
+// runVdfSet sets the value at a slash-separated key path, creating missing
+// blocks, and writes the file back with WriteVDFFile, keeping a backup.
+func runVdfSet(args []string) error {
+	fs := newFlagSet("vdf set", "<file> <key/path> <value>")
+	if err := parseFlags(fs, args, 3, 3); err != nil {
+		return err
+	}
+
+	path := fs.Arg(0)
+	vdfMap, err := readVdfFile(path)
+	if err != nil {
+		return err
+	}
+
+	keys := splitKeyPath(fs.Arg(1))
+	if len(keys) == 0 {
+		return fmt.Errorf("%w: empty key path", errUsage)
+	}
+
+	block := vdfMap
+	for _, key := range keys[:len(keys)-1] {
+		actual, found := findKey(block, key)
+		if !found {
+			child := orderedmap.New()
+			block.Set(key, child)
+			block = child
+			continue
+		}
+
+		childVal, _ := block.Get(actual)
+		child, ok := childVal.(*orderedmap.OrderedMap)
+		if !ok {
+			return fmt.Errorf("%s is a value, not a block", key)
+		}
+		block = child
+	}
+
+	last := keys[len(keys)-1]
+	if actual, found := findKey(block, last); found {
+		if existing, _ := block.Get(actual); existing != nil {
+			if _, isBlock := existing.(*orderedmap.OrderedMap); isBlock {
+				return fmt.Errorf("%s is a block, not a value", last)
+			}
+		}
+		last = actual
+	}
+	block.Set(last, fs.Arg(2))
+
+	return steamutils.WriteVDFFile(path, vdfMap)
+}
+
+// runVdfRestore rolls a VDF file back to one of the backups WriteVDFFile keeps.
+func runVdfRestore(args []string) error {
+	fs := newFlagSet("vdf restore", "<file> [versions]")
+	if err := parseFlags(fs, args, 1, 2); err != nil {
+		return err
+	}
+
+	versions := 1
+	if fs.NArg() == 2 {
+		n, err := strconv.Atoi(fs.Arg(1))
+		if err != nil {
+			return fmt.Errorf("%w: invalid version count %q", errUsage, fs.Arg(1))
+		}
+		versions = n
+	}
+	return steamutils.RestoreVDFFile(fs.Arg(0), versions)
+}
+
+// readVdfFile reads and parses a VDF file.
+func readVdfFile(path string) (*orderedmap.OrderedMap, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	vdfMap, err := steamutils.Unmarshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return vdfMap, nil
+}
+
+// splitKeyPath splits "a/b/c" into its keys, ignoring empty segments.
+func splitKeyPath(keyPath string) []string {
+	var keys []string
+	for _, key := range strings.Split(keyPath, "/") {
+		if key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// findKey returns the key of block that matches key case-insensitively.
+func findKey(block *orderedmap.OrderedMap, key string) (string, bool) {
+	if _, exists := block.Get(key); exists {
+		return key, true
+	}
+	for _, k := range block.Keys() {
+		if strings.EqualFold(k, key) {
+			return k, true
+		}
+	}
+	return "", false
+}
+
+// The code in this file was made by an LLM, use in production is highly discouraged as unexpected results may occur. The code in this file is not vetted for stability or edge cases.
@@ -0,0 +1,61 @@
+package steamutils
+
+import (
+	"errors"
+	"os"
+)
+
+// ErrLibraryUnavailable is returned when an app is recorded in a library whose
+// media is not currently mounted, such as an ejected SD card.
+var ErrLibraryUnavailable = errors.New("library is not available")
+
+// DeviceClass describes the kind of storage backing a library folder.
+type DeviceClass string
+
+// Known device classes.
+const (
+	DeviceUnknown   DeviceClass = "unknown"
+	DeviceInternal  DeviceClass = "internal"
+	DeviceRemovable DeviceClass = "removable"
+	DeviceNetwork   DeviceClass = "network"
+)
+
+// Platform describes the operating system Steam runs on.
+//
+// WARNING: This type was generated by an LLM. Thoroughly test before production use.
+type Platform struct {
+	// OS is the Go operating system name, such as "linux".
+	OS string
+
+	// SteamOS reports whether the system is running SteamOS.
+	SteamOS bool
+
+	// SteamDeck reports whether the hardware is a Steam Deck.
+	SteamDeck bool
+
+	// DeviceModel is the Steam Deck board name ("Jupiter" for LCD models,
+	// "Galileo" for OLED models), or "" on other hardware.
+	DeviceModel string
+}
+
+// DetectPlatform reports whether the system is SteamOS and whether it runs on
+// Steam Deck hardware.
+//
+// WARNING: This function was generated by an LLM. Thoroughly test before production use.
+func DetectPlatform() Platform {
+	return detectPlatform()
+}
+
+// libraryStorage classifies the device backing a library folder and reports
+// whether the library is currently reachable.
+func libraryStorage(path string) (DeviceClass, bool) {
+	class, mounted := classifyLibraryDevice(path)
+	if !mounted {
+		return class, false
+	}
+
+	_, err := os.Stat(path)
+	return class, err == nil
+}
+
+// The code in this file was made by an LLM, use in production is highly discouraged as unexpected results may occur. The code in this file is not vetted for stability or edge cases.
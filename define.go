@@ -1,6 +1,10 @@
 package steamutils
 
-import "github.com/iancoleman/orderedmap"
+import (
+	"time"
+
+	"github.com/iancoleman/orderedmap"
+)
 
 // SteamReader provides methods to query Steam installation and application data.
 //
@@ -11,17 +15,16 @@ import "github.com/iancoleman/orderedmap"
 type SteamReader struct {
 	libraryVdfPath    string
 	steamPath         string
+	homeDir           string
 	libraryVdfMap     *orderedmap.OrderedMap
 	SteamReaderConfig SteamReaderConfig
 }
 
-var customUser string
-
 // SteamReaderConfig provides configuration options for creating a new SteamReader.
 //
 // LibraryVdfPathFinder and SteamPathFinder allow custom path detection logic.
 // CustomLibraryVdfPath and CustomSteamPath override automatic detection.
-// FormatSteamPath determines whether to normalize path formatting.
+// FormatSteamPath determines whether returned paths are canonicalized.
 //
 // WARNING: This type was generated by an LLM. Thoroughly test before production use.
 type SteamReaderConfig struct {
@@ -33,24 +36,45 @@ type SteamReaderConfig struct {
 	// If nil, platform-specific detection is used (registry on Windows, paths on Linux/macOS).
 	SteamPathFinder func() (string, error)
 
-	// customSteamPathFinder indicates whether a custom SteamPathFinder is in use.
-	customSteamPathFinder bool
-
 	// CustomLibraryVdfPath overrides the detected libraryfolders.vdf path.
-	// If set, LibraryVdfPathFinder is not called.
+	// NewSteamReader fails if the file cannot be read or parsed;
+	// LibraryVdfPathFinder is only called if it is empty.
 	CustomLibraryVdfPath string
 
 	// CustomSteamPath overrides the detected Steam installation path.
-	// If set, SteamPathFinder is not called.
+	// NewSteamReader fails if the directory does not contain a Steam
+	// installation; SteamPathFinder is only called if it is empty.
 	CustomSteamPath string
 
-	// FormatSteamPath normalizes the Steam path formatting.
-	// Applies path separator normalization and case corrections.
-	// Only affects paths from the default SteamPathFinder.
+	// FormatSteamPath canonicalizes the Steam path, library paths and app
+	// FullPaths returned by the reader: symlinks are resolved and, on Windows,
+	// the on-disk casing and long (non 8.3) names are used. Paths that do not
+	// exist are only cleaned.
 	FormatSteamPath bool
 
-	// UserName is used for Linux installations only, used to find steamPath
+	// PathMappings translates library paths recorded for another OS into
+	// local paths, for example to read a Windows Steam library from Linux
+	// through WSLPathMappings or WinePathMappings. The longest matching
+	// prefix wins; unmatched paths are used as recorded.
+	PathMappings []PathMapping
+
+	// HomeDir is the home directory Steam is looked up in on Linux and macOS.
+	// If empty, the home of UserName is used, or the current user's.
+	HomeDir string
+
+	// UserName is the OS account whose home directory is used to find Steam
+	// on Linux and macOS. Ignored if HomeDir is set.
 	UserName string
+
+	// InstallationKind selects a discovered installation of this kind when
+	// several are present, for example InstallationFlatpak.
+	// Ignored if CustomSteamPath or SteamPathFinder is set.
+	InstallationKind InstallationKind
+
+	// InstallationPath selects the discovered installation at this path or one
+	// of its aliases. May be combined with InstallationKind.
+	// Ignored if CustomSteamPath or SteamPathFinder is set.
+	InstallationPath string
 }
 
 // InstalledDepot represents a Steam depot (content package) installed for an application.
@@ -61,10 +85,10 @@ type SteamReaderConfig struct {
 // WARNING: This type was generated by an LLM. Thoroughly test before production use.
 type InstalledDepot struct {
 	// DepotID is the unique identifier for this depot.
-	DepotID string
+	DepotID uint64
 
-	// Manifest is the content manifest identifier for this depot version.
-	Manifest string
+	// Manifest is the 64-bit content manifest GID for this depot version.
+	Manifest uint64
 
 	// Size is the total size of the depot in bytes.
 	Size int64
@@ -99,18 +123,49 @@ type InstalledApp struct {
 	// SizeOnDisk is the total installation size in bytes.
 	SizeOnDisk int64
 
-	// LastUpdated is the Unix timestamp of the last update.
-	LastUpdated int64
+	// LastUpdated is the time of the last update, or the zero time if unknown.
+	LastUpdated time.Time
+
+	// LastPlayed is the time of the last play session.
+	// The zero time indicates the application has never been played.
+	LastPlayed time.Time
 
-	// LastPlayed is the Unix timestamp of the last play session.
-	// Zero value indicates the application has never been played.
-	LastPlayed int64
+	// StateFlags is the raw AppState StateFlags bit field; 4 means fully
+	// installed, other bits mark pending updates, validation and the like.
+	StateFlags int64
+
+	// BytesToDownload is the size of the pending or running update download.
+	BytesToDownload int64
+
+	// BytesDownloaded is how much of BytesToDownload has been downloaded.
+	BytesDownloaded int64
+
+	// AutoUpdateBehavior is the app's "Automatic updates" setting.
+	AutoUpdateBehavior AutoUpdateBehavior
+
+	// AllowOtherDownloadsWhileRunning is the app's "Background downloads"
+	// setting.
+	AllowOtherDownloadsWhileRunning BackgroundDownloads
 
 	// LibraryPath is the path to the Steam library containing this application.
 	LibraryPath string
 
 	// InstalledDepots is the list of content depots installed for this application.
 	InstalledDepots []InstalledDepot
+
+	// Raw is the manifest's complete AppState block, including keys the fields
+	// above do not cover. Nil for unavailable apps.
+	Raw *orderedmap.OrderedMap
+
+	// Unavailable is set when the app's library cannot be reached, such as an
+	// SD card that is not mounted. Only AppID, LibraryPath and the
+	// SizeOnDisk recorded in libraryfolders.vdf are filled in.
+	Unavailable bool
+
+	// ManifestError is the reason the app's manifest could not be read or
+	// parsed, or "" if it was. Apps with a ManifestError are filled in like
+	// unavailable ones.
+	ManifestError string
 }
 
 // The code in this file was made by an LLM, use in production is highly discouraged as unexpected results may occur. The code in this file is not vetted for stability or edge cases.
@@ -0,0 +1,433 @@
+package steamutils
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Section markers used by Steam's binary depot manifest format. Each section is
+// a little-endian uint32 magic, a uint32 length and a protobuf message.
+const (
+	depotManifestPayloadMagic   uint32 = 0x71F617D0
+	depotManifestMetadataMagic  uint32 = 0x1F4812BE
+	depotManifestSignatureMagic uint32 = 0x1B81B817
+	depotManifestEndMagic       uint32 = 0x32C415AB
+)
+
+// DepotFileFlag holds the EDepotFileFlag bits of a file in a depot manifest.
+type DepotFileFlag uint32
+
+// Known DepotFileFlag bits.
+const (
+	DepotFileFlagUserConfig          DepotFileFlag = 1 << 0
+	DepotFileFlagVersionedUserConfig DepotFileFlag = 1 << 1
+	DepotFileFlagEncrypted           DepotFileFlag = 1 << 2
+	DepotFileFlagReadOnly            DepotFileFlag = 1 << 3
+	DepotFileFlagHidden              DepotFileFlag = 1 << 4
+	DepotFileFlagExecutable          DepotFileFlag = 1 << 5
+	DepotFileFlagDirectory           DepotFileFlag = 1 << 6
+	DepotFileFlagCustomExecutable    DepotFileFlag = 1 << 7
+	DepotFileFlagInstallScript       DepotFileFlag = 1 << 8
+	DepotFileFlagSymlink             DepotFileFlag = 1 << 9
+)
+
+// DepotChunk is one content chunk of a file in a depot manifest.
+type DepotChunk struct {
+	// SHA is the SHA-1 of the chunk's uncompressed data.
+	SHA []byte
+
+	// CRC is the Adler-style checksum Steam stores for the chunk.
+	CRC uint32
+
+	// Offset is the chunk's position within the file.
+	Offset uint64
+
+	// OriginalSize is the uncompressed size of the chunk in bytes.
+	OriginalSize uint32
+
+	// CompressedSize is the compressed size of the chunk in bytes.
+	CompressedSize uint32
+}
+
+// DepotFile is a file, directory or symlink listed in a depot manifest.
+type DepotFile struct {
+	// Name is the path relative to the application's install directory,
+	// using the local path separator.
+	Name string
+
+	// Size is the file size in bytes.
+	Size uint64
+
+	// Flags holds the EDepotFileFlag bits for the file.
+	Flags DepotFileFlag
+
+	// SHAFilename is the SHA-1 of the lower-cased file name.
+	SHAFilename []byte
+
+	// SHAContent is the SHA-1 of the file's content.
+	SHAContent []byte
+
+	// Chunks lists the content chunks making up the file.
+	Chunks []DepotChunk
+
+	// LinkTarget is the symlink target for symlink entries.
+	LinkTarget string
+}
+
+// IsDirectory reports whether the entry describes a directory.
+func (file DepotFile) IsDirectory() bool {
+	return file.Flags&DepotFileFlagDirectory != 0
+}
+
+// DepotManifest is a decoded depotcache/<depotid>_<manifestid>.manifest file.
+//
+// WARNING: This type was generated by an LLM. Thoroughly test before production use.
+type DepotManifest struct {
+	// DepotID is the depot the manifest belongs to, matching InstalledDepot.DepotID.
+	DepotID uint64
+
+	// ManifestID is the manifest GID, matching InstalledDepot.Manifest.
+	ManifestID uint64
+
+	// CreationTime is when the manifest was built.
+	CreationTime time.Time
+
+	// FilenamesEncrypted reports whether file names are still encrypted with
+	// the depot key. Names are base64 ciphertext in that case.
+	FilenamesEncrypted bool
+
+	// OriginalSize is the total uncompressed size of all files.
+	OriginalSize uint64
+
+	// CompressedSize is the total compressed size of all chunks.
+	CompressedSize uint64
+
+	// UniqueChunks is the number of distinct chunks in the depot.
+	UniqueChunks uint32
+
+	// CRCEncrypted and CRCClear are the checksums of the payload section.
+	CRCEncrypted uint32
+	CRCClear     uint32
+
+	// Files lists every entry in the manifest.
+	Files []DepotFile
+
+	// Signature is the raw manifest signature, if present.
+	Signature []byte
+}
+
+// DepotManifestDiff lists the files that differ between two depot manifests.
+type DepotManifestDiff struct {
+	Added    []string
+	Removed  []string
+	Modified []string
+}
+
+// ReadDepotManifest reads and decodes a depot manifest file.
+//
+// WARNING: This function was generated by an LLM. Thoroughly test before production use.
+func ReadDepotManifest(path string) (*DepotManifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read depot manifest: %w", err)
+	}
+
+	return DecodeDepotManifest(data)
+}
+
+// DecodeDepotManifest decodes the binary depot manifest format Steam stores in
+// its depotcache directory, including the zip-wrapped variant.
+//
+// WARNING: This function was generated by an LLM. Thoroughly test before production use.
+func DecodeDepotManifest(data []byte) (*DepotManifest, error) {
+	// Manifests downloaded from the CDN are a zip archive holding a single file.
+	if bytes.HasPrefix(data, []byte("PK\x03\x04")) {
+		unzipped, err := unzipDepotManifest(data)
+		if err != nil {
+			return nil, err
+		}
+		data = unzipped
+	}
+
+	manifest := &DepotManifest{}
+	var payload []byte
+	i := 0
+	for i < len(data) {
+		if len(data)-i < 4 {
+			return nil, fmt.Errorf("truncated section header at offset %d", i)
+		}
+		magic := binary.LittleEndian.Uint32(data[i:])
+		i += 4
+		if magic == depotManifestEndMagic {
+			break
+		}
+
+		if len(data)-i < 4 {
+			return nil, fmt.Errorf("truncated section length at offset %d", i)
+		}
+		length := int(binary.LittleEndian.Uint32(data[i:]))
+		i += 4
+		if length < 0 || len(data)-i < length {
+			return nil, fmt.Errorf("section 0x%08x at offset %d overruns file", magic, i)
+		}
+		section := data[i : i+length]
+		i += length
+
+		switch magic {
+		case depotManifestPayloadMagic:
+			payload = section
+		case depotManifestMetadataMagic:
+			if err := decodeDepotManifestMetadata(section, manifest); err != nil {
+				return nil, fmt.Errorf("failed to decode manifest metadata: %w", err)
+			}
+		case depotManifestSignatureMagic:
+			err := walkProtobuf(section, func(field int, wireType int, value uint64, raw []byte) error {
+				if field == 1 && wireType == protoBytes {
+					manifest.Signature = append([]byte(nil), raw...)
+				}
+				return nil
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode manifest signature: %w", err)
+			}
+		default:
+			return nil, fmt.Errorf("unknown section magic 0x%08x", magic)
+		}
+	}
+
+	if payload == nil {
+		return nil, errors.New("depot manifest has no payload section")
+	}
+
+	err := walkProtobuf(payload, func(field int, wireType int, value uint64, raw []byte) error {
+		if field != 1 || wireType != protoBytes {
+			return nil
+		}
+		file, err := decodeDepotFile(raw)
+		if err != nil {
+			return err
+		}
+		manifest.Files = append(manifest.Files, file)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode manifest payload: %w", err)
+	}
+
+	return manifest, nil
+}
+
+// GetDepotManifest reads depotcache/<depotID>_<manifestID>.manifest from the
+// Steam installation directory.
+//
+// WARNING: This method was generated by an LLM. Thoroughly test before production use.
+func (steamreader *SteamReader) GetDepotManifest(depotID, manifestID uint64) (*DepotManifest, error) {
+	return ReadDepotManifest(filepath.Join(steamreader.steamPath, "depotcache", fmt.Sprintf("%d_%d.manifest", depotID, manifestID)))
+}
+
+// DiffDepotManifests compares the files of two manifests of the same depot.
+// A file is reported as modified when its size or content hash changed.
+//
+// WARNING: This function was generated by an LLM. Thoroughly test before production use.
+func DiffDepotManifests(oldManifest, newManifest *DepotManifest) DepotManifestDiff {
+	var diff DepotManifestDiff
+
+	oldFiles := make(map[string]DepotFile, len(oldManifest.Files))
+	for _, file := range oldManifest.Files {
+		oldFiles[file.Name] = file
+	}
+
+	for _, file := range newManifest.Files {
+		oldFile, exists := oldFiles[file.Name]
+		if !exists {
+			diff.Added = append(diff.Added, file.Name)
+			continue
+		}
+		delete(oldFiles, file.Name)
+
+		if oldFile.Size != file.Size || !bytes.Equal(oldFile.SHAContent, file.SHAContent) {
+			diff.Modified = append(diff.Modified, file.Name)
+		}
+	}
+
+	for name := range oldFiles {
+		diff.Removed = append(diff.Removed, name)
+	}
+	sort.Strings(diff.Removed)
+
+	return diff
+}
+
+// unzipDepotManifest extracts the single entry of a zip-wrapped manifest.
+func unzipDepotManifest(data []byte) ([]byte, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open zipped depot manifest: %w", err)
+	}
+	if len(archive.File) == 0 {
+		return nil, errors.New("zipped depot manifest is empty")
+	}
+
+	f, err := archive.File[0].Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open zipped depot manifest: %w", err)
+	}
+	defer f.Close()
+
+	return io.ReadAll(f)
+}
+
+// decodeDepotManifestMetadata decodes a ContentManifestMetadata message.
+func decodeDepotManifestMetadata(data []byte, manifest *DepotManifest) error {
+	return walkProtobuf(data, func(field int, wireType int, value uint64, raw []byte) error {
+		switch field {
+		case 1:
+			manifest.DepotID = value
+		case 2:
+			manifest.ManifestID = value
+		case 3:
+			manifest.CreationTime = time.Unix(int64(uint32(value)), 0)
+		case 4:
+			manifest.FilenamesEncrypted = value != 0
+		case 5:
+			manifest.OriginalSize = value
+		case 6:
+			manifest.CompressedSize = value
+		case 7:
+			manifest.UniqueChunks = uint32(value)
+		case 8:
+			manifest.CRCEncrypted = uint32(value)
+		case 9:
+			manifest.CRCClear = uint32(value)
+		}
+		return nil
+	})
+}
+
+// decodeDepotFile decodes a ContentManifestPayload.FileMapping message.
+func decodeDepotFile(data []byte) (DepotFile, error) {
+	var file DepotFile
+	err := walkProtobuf(data, func(field int, wireType int, value uint64, raw []byte) error {
+		switch field {
+		case 1:
+			// Depots built on Windows use backslashes regardless of target OS.
+			name := strings.ReplaceAll(string(raw), "\\", "/")
+			file.Name = filepath.FromSlash(strings.TrimRight(name, "\x00"))
+		case 2:
+			file.Size = value
+		case 3:
+			file.Flags = DepotFileFlag(value)
+		case 4:
+			file.SHAFilename = append([]byte(nil), raw...)
+		case 5:
+			file.SHAContent = append([]byte(nil), raw...)
+		case 6:
+			chunk, err := decodeDepotChunk(raw)
+			if err != nil {
+				return err
+			}
+			file.Chunks = append(file.Chunks, chunk)
+		case 7:
+			file.LinkTarget = string(raw)
+		}
+		return nil
+	})
+	return file, err
+}
+
+// decodeDepotChunk decodes a ContentManifestPayload.FileMapping.ChunkData message.
+func decodeDepotChunk(data []byte) (DepotChunk, error) {
+	var chunk DepotChunk
+	err := walkProtobuf(data, func(field int, wireType int, value uint64, raw []byte) error {
+		switch field {
+		case 1:
+			chunk.SHA = append([]byte(nil), raw...)
+		case 2:
+			chunk.CRC = uint32(value)
+		case 3:
+			chunk.Offset = value
+		case 4:
+			chunk.OriginalSize = uint32(value)
+		case 5:
+			chunk.CompressedSize = uint32(value)
+		}
+		return nil
+	})
+	return chunk, err
+}
+
+// Protobuf wire types understood by walkProtobuf.
+const (
+	protoVarint  = 0
+	protoFixed64 = 1
+	protoBytes   = 2
+	protoFixed32 = 5
+)
+
+// walkProtobuf calls fn for every field of a protobuf message. Numeric fields
+// are passed in value, length-delimited fields in raw.
+func walkProtobuf(data []byte, fn func(field int, wireType int, value uint64, raw []byte) error) error {
+	i := 0
+	for i < len(data) {
+		key, n := binary.Uvarint(data[i:])
+		if n <= 0 {
+			return fmt.Errorf("invalid field key at offset %d", i)
+		}
+		i += n
+
+		field := int(key >> 3)
+		wireType := int(key & 7)
+
+		var value uint64
+		var raw []byte
+		switch wireType {
+		case protoVarint:
+			value, n = binary.Uvarint(data[i:])
+			if n <= 0 {
+				return fmt.Errorf("invalid varint at offset %d", i)
+			}
+			i += n
+		case protoFixed64:
+			if len(data)-i < 8 {
+				return fmt.Errorf("truncated fixed64 at offset %d", i)
+			}
+			value = binary.LittleEndian.Uint64(data[i:])
+			i += 8
+		case protoBytes:
+			length, n := binary.Uvarint(data[i:])
+			if n <= 0 {
+				return fmt.Errorf("invalid length at offset %d", i)
+			}
+			i += n
+			if uint64(len(data)-i) < length {
+				return fmt.Errorf("field %d at offset %d overruns message", field, i)
+			}
+			raw = data[i : i+int(length)]
+			i += int(length)
+		case protoFixed32:
+			if len(data)-i < 4 {
+				return fmt.Errorf("truncated fixed32 at offset %d", i)
+			}
+			value = uint64(binary.LittleEndian.Uint32(data[i:]))
+			i += 4
+		default:
+			return fmt.Errorf("unsupported wire type %d at offset %d", wireType, i)
+		}
+
+		if err := fn(field, wireType, value, raw); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// The code in this file was made by an LLM, use in production is highly discouraged as unexpected results may occur. The code in this file is not vetted for stability or edge cases.
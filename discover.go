@@ -0,0 +1,224 @@
+package steamutils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+)
+
+// InstallationKind identifies how a Steam installation was installed.
+type InstallationKind string
+
+// Known installation kinds.
+const (
+	// InstallationNative is the distribution or Valve package, or the regular
+	// client on Windows and macOS.
+	InstallationNative InstallationKind = "native"
+
+	// InstallationFlatpak is the com.valvesoftware.Steam Flatpak.
+	InstallationFlatpak InstallationKind = "flatpak"
+
+	// InstallationSnap is the steam Snap package.
+	InstallationSnap InstallationKind = "snap"
+
+	// InstallationSystem is a system-wide installation, such as /usr/share/steam
+	// or the HKLM InstallPath on Windows.
+	InstallationSystem InstallationKind = "system"
+
+	// InstallationEnv is the directory named by the STEAM_PATH environment variable.
+	InstallationEnv InstallationKind = "env"
+)
+
+// SteamInstallation is a Steam installation found on this machine.
+//
+// WARNING: This type was generated by an LLM. Thoroughly test before production use.
+type SteamInstallation struct {
+	// Kind is how the installation was installed.
+	Kind InstallationKind
+
+	// Path is the canonical installation directory with symlinks resolved.
+	Path string
+
+	// Aliases lists the other known paths that resolve to Path, such as the
+	// ~/.steam/steam symlink.
+	Aliases []string
+
+	// LastUsed is the most recent modification time of the installation's
+	// configuration files, or the zero time if none exist.
+	LastUsed time.Time
+}
+
+// installationCandidate is a path where a Steam installation of a given kind
+// may be found.
+type installationCandidate struct {
+	kind InstallationKind
+	path string
+}
+
+// DiscoverSteamInstallations returns every Steam installation found for the
+// current user, instead of only the first match like GetSteamPath.
+//
+// Candidate paths that resolve to the same directory are merged, keeping the
+// kind of the first candidate and listing the others as aliases. The result is
+// ordered with the most recently used installation first.
+//
+// WARNING: This function was generated by an LLM. Thoroughly test before production use.
+func DiscoverSteamInstallations() ([]SteamInstallation, error) {
+	homeDir, err := currentHomeDir()
+	if err != nil {
+		return nil, err
+	}
+
+	return discoverSteamInstallations(homeDir)
+}
+
+// discoverSteamInstallations finds the Steam installations of the OS user whose
+// home directory is homeDir.
+func discoverSteamInstallations(homeDir string) ([]SteamInstallation, error) {
+	candidates, err := steamPathCandidates(homeDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var installations []SteamInstallation
+	index := make(map[string]int)
+	for _, candidate := range candidates {
+		if candidate.path == "" {
+			continue
+		}
+
+		resolved, err := filepath.EvalSymlinks(candidate.path)
+		if err != nil {
+			continue
+		}
+		resolved = filepath.Clean(resolved)
+
+		if !looksLikeSteamDir(resolved) {
+			continue
+		}
+
+		key := resolved
+		if runtime.GOOS == "windows" {
+			key = strings.ToLower(key)
+		}
+
+		if i, exists := index[key]; exists {
+			installation := &installations[i]
+			if candidate.path != resolved && !containsString(installation.Aliases, candidate.path) {
+				installation.Aliases = append(installation.Aliases, candidate.path)
+			}
+			continue
+		}
+
+		installation := SteamInstallation{
+			Kind:     candidate.kind,
+			Path:     resolved,
+			LastUsed: installationLastUsed(resolved),
+		}
+		if candidate.path != resolved {
+			installation.Aliases = append(installation.Aliases, candidate.path)
+		}
+
+		index[key] = len(installations)
+		installations = append(installations, installation)
+	}
+
+	sort.SliceStable(installations, func(i, j int) bool {
+		return installations[i].LastUsed.After(installations[j].LastUsed)
+	})
+
+	return installations, nil
+}
+
+// selectSteamInstallation returns the path of the discovered installation
+// matching kind and path. Either may be empty to match anything; when several
+// installations match, the most recently used one wins.
+func selectSteamInstallation(homeDir string, kind InstallationKind, path string) (string, error) {
+	installations, err := discoverSteamInstallations(homeDir)
+	if err != nil {
+		return "", err
+	}
+
+	for _, installation := range installations {
+		if kind != "" && installation.Kind != kind {
+			continue
+		}
+		if path != "" && !installation.matchesPath(path) {
+			continue
+		}
+		return installation.Path, nil
+	}
+
+	return "", fmt.Errorf("no Steam installation found matching kind %q and path %q", kind, path)
+}
+
+// matchesPath reports whether path names this installation, either directly,
+// through one of its aliases or after resolving symlinks.
+func (installation SteamInstallation) matchesPath(path string) bool {
+	if samePath(path, installation.Path) {
+		return true
+	}
+	for _, alias := range installation.Aliases {
+		if samePath(path, alias) {
+			return true
+		}
+	}
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		return samePath(resolved, installation.Path)
+	}
+	return false
+}
+
+// installationLastUsed returns the latest modification time of the files Steam
+// rewrites whenever the client runs.
+func installationLastUsed(path string) time.Time {
+	var lastUsed time.Time
+	for _, file := range []string{
+		filepath.Join(path, "config", "config.vdf"),
+		filepath.Join(path, "config", "loginusers.vdf"),
+		filepath.Join(path, "steamapps", "libraryfolders.vdf"),
+	} {
+		if info, err := os.Stat(file); err == nil && info.ModTime().After(lastUsed) {
+			lastUsed = info.ModTime()
+		}
+	}
+	return lastUsed
+}
+
+// looksLikeSteamDir reports whether path exists and contains Steam's steamapps,
+// userdata or config directories.
+func looksLikeSteamDir(path string) bool {
+	// Check if directory exists and contains Steam
+	if _, err := os.Stat(filepath.Join(path, "steamapps")); err == nil {
+		return true
+	}
+
+	// Some installations might have steamapps as a symlink
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return false
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if name == "steamapps" || name == "userdata" || name == "config" {
+			return true
+		}
+	}
+	return false
+}
+
+// containsString reports whether list contains s.
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// The code in this file was made by an LLM, use in production is highly discouraged as unexpected results may occur. The code in this file is not vetted for stability or edge cases.
@@ -0,0 +1,450 @@
+package steamutils
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/iancoleman/orderedmap"
+)
+
+// StatType is the kind of value a stat holds.
+type StatType string
+
+// Stat types.
+const (
+	StatInt     StatType = "int"
+	StatFloat   StatType = "float"
+	StatAvgRate StatType = "avgrate"
+)
+
+// Stat is one stat of a game's stats schema, with the account's value when
+// read through ReadUserGameStats.
+//
+// WARNING: This type was generated by an LLM. Thoroughly test before production use.
+type Stat struct {
+	ID          int
+	Name        string
+	DisplayName string
+	Type        StatType
+
+	// Default is the value of a stat the account never set.
+	Default float64
+
+	// Value is the account's value, or Default if it has none.
+	Value float64
+}
+
+// Achievement is one achievement of a game's stats schema, with the account's
+// progress when read through ReadUserGameStats.
+//
+// WARNING: This type was generated by an LLM. Thoroughly test before production use.
+type Achievement struct {
+	// Name is the API name, such as "ACH_WIN_ONE_GAME".
+	Name string
+
+	// StatID and Bit locate the achievement's unlock bit in the stats.
+	StatID int
+	Bit    int
+
+	// DisplayName and Description map a Steam language name, such as
+	// "english" or "german", to the localized text.
+	DisplayName map[string]string
+	Description map[string]string
+
+	// Hidden achievements should not show their description until unlocked.
+	Hidden bool
+
+	// Icon and IconGray are the file names of the unlocked and locked icons
+	// on the Steam CDN.
+	Icon     string
+	IconGray string
+
+	Unlocked bool
+
+	// UnlockTime is the time the achievement was unlocked, zero if locked or
+	// not recorded.
+	UnlockTime time.Time
+}
+
+// LocalizedName returns the display name in language, falling back to
+// English and then to Name.
+func (achievement *Achievement) LocalizedName(language string) string {
+	return localizedText(achievement.DisplayName, language, achievement.Name)
+}
+
+// LocalizedDescription returns the description in language, falling back to
+// English.
+func (achievement *Achievement) LocalizedDescription(language string) string {
+	return localizedText(achievement.Description, language, "")
+}
+
+// GameStatsSchema is the parsed appcache/stats/UserGameStatsSchema_<appid>.bin.
+//
+// WARNING: This type was generated by an LLM. Thoroughly test before production use.
+type GameStatsSchema struct {
+	AppID        string
+	GameName     string
+	Version      int64
+	Stats        []Stat
+	Achievements []Achievement
+}
+
+// UserGameStats is a game's stats schema joined with one account's
+// appcache/stats/UserGameStats_<accountid>_<appid>.bin.
+//
+// WARNING: This type was generated by an LLM. Thoroughly test before production use.
+type UserGameStats struct {
+	AppID        string
+	AccountID    string
+	GameName     string
+	Stats        []Stat
+	Achievements []Achievement
+
+	// Unlocked is the number of unlocked achievements out of Total.
+	Unlocked int
+	Total    int
+}
+
+// ReadStatsSchema parses the stats and achievements schema Steam caches for
+// appID.
+//
+// WARNING: This method was generated by an LLM. Thoroughly test before production use.
+func (steamreader *SteamReader) ReadStatsSchema(appID string) (*GameStatsSchema, error) {
+	if _, err := strconv.ParseUint(appID, 10, 32); err != nil {
+		return nil, fmt.Errorf("invalid app ID %q", appID)
+	}
+
+	path := filepath.Join(steamreader.statsDir(), "UserGameStatsSchema_"+appID+".bin")
+	root, err := readStatsFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	schema := &GameStatsSchema{AppID: appID, GameName: vdfString(root, "gamename")}
+	if schema.Version, err = manifestInt(root, "version"); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	statsVal, _ := vdfLookup(root, "stats")
+	stats, _ := statsVal.(*orderedmap.OrderedMap)
+	if stats == nil {
+		return schema, nil
+	}
+
+	for _, key := range stats.Keys() {
+		statVal, _ := stats.Get(key)
+		statBlock, ok := statVal.(*orderedmap.OrderedMap)
+		if !ok {
+			continue
+		}
+
+		id, err := strconv.Atoi(key)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid stat ID %q", path, key)
+		}
+
+		switch typ := strings.ToLower(vdfString(statBlock, "type")); typ {
+		case "4", "5", "achievements", "groupachievements":
+			achievements, err := schemaAchievements(id, statBlock)
+			if err != nil {
+				return nil, fmt.Errorf("%s: stat %d: %w", path, id, err)
+			}
+			schema.Achievements = append(schema.Achievements, achievements...)
+		default:
+			stat := Stat{
+				ID:          id,
+				Name:        vdfString(statBlock, "name"),
+				DisplayName: vdfString(statBlock, "display", "name"),
+				Type:        statTypes[typ],
+			}
+			if stat.Type == "" {
+				return nil, fmt.Errorf("%s: stat %d: unknown type %q", path, id, typ)
+			}
+			if value := vdfString(statBlock, "default"); value != "" {
+				if stat.Default, err = strconv.ParseFloat(value, 64); err != nil {
+					return nil, fmt.Errorf("%s: stat %d: invalid default %q", path, id, value)
+				}
+			}
+			stat.Value = stat.Default
+			schema.Stats = append(schema.Stats, stat)
+		}
+	}
+	return schema, nil
+}
+
+// ReadUserGameStats reads the stats schema of appID and fills in the values
+// and achievement progress of accountID. An account with no stats file for
+// the game has every stat at its default and no achievement unlocked.
+//
+// WARNING: This method was generated by an LLM. Thoroughly test before production use.
+func (steamreader *SteamReader) ReadUserGameStats(accountID, appID string) (*UserGameStats, error) {
+	if _, err := strconv.ParseUint(accountID, 10, 32); err != nil {
+		return nil, fmt.Errorf("invalid account ID %q", accountID)
+	}
+
+	schema, err := steamreader.ReadStatsSchema(appID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &UserGameStats{
+		AppID:        appID,
+		AccountID:    accountID,
+		GameName:     schema.GameName,
+		Stats:        schema.Stats,
+		Achievements: schema.Achievements,
+		Total:        len(schema.Achievements),
+	}
+
+	path := filepath.Join(steamreader.statsDir(), "UserGameStats_"+accountID+"_"+appID+".bin")
+	root, err := readStatsFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return stats, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	data := map[int]uint32{}
+	times := map[int]*orderedmap.OrderedMap{}
+	for _, key := range root.Keys() {
+		id, err := strconv.Atoi(key)
+		if err != nil {
+			continue
+		}
+		statVal, _ := root.Get(key)
+		statBlock, ok := statVal.(*orderedmap.OrderedMap)
+		if !ok {
+			continue
+		}
+
+		if data[id], err = statData(statBlock); err != nil {
+			return nil, fmt.Errorf("%s: stat %d: %w", path, id, err)
+		}
+		if timesVal, ok := vdfLookup(statBlock, "AchievementTimes"); ok {
+			times[id], _ = timesVal.(*orderedmap.OrderedMap)
+		}
+	}
+
+	for i := range stats.Stats {
+		stat := &stats.Stats[i]
+		bits, ok := data[stat.ID]
+		if !ok {
+			continue
+		}
+		if stat.Type == StatInt {
+			stat.Value = float64(int32(bits))
+		} else {
+			stat.Value = float64(math.Float32frombits(bits))
+		}
+	}
+
+	for i := range stats.Achievements {
+		achievement := &stats.Achievements[i]
+		if achievement.Bit < 0 || achievement.Bit > 31 || data[achievement.StatID]&(1<<achievement.Bit) == 0 {
+			continue
+		}
+		achievement.Unlocked = true
+		stats.Unlocked++
+
+		if unlockTimes := times[achievement.StatID]; unlockTimes != nil {
+			if achievement.UnlockTime, err = manifestTime(unlockTimes, strconv.Itoa(achievement.Bit)); err != nil {
+				return nil, fmt.Errorf("%s: achievement %s: %w", path, achievement.Name, err)
+			}
+		}
+	}
+	return stats, nil
+}
+
+// GetAllUserGameStats reads the stats of every game accountID has a stats
+// file for, sorted by game name. Games whose schema is not cached are left
+// out.
+//
+// WARNING: This method was generated by an LLM. Thoroughly test before production use.
+func (steamreader *SteamReader) GetAllUserGameStats(accountID string) ([]UserGameStats, error) {
+	if _, err := strconv.ParseUint(accountID, 10, 32); err != nil {
+		return nil, fmt.Errorf("invalid account ID %q", accountID)
+	}
+
+	entries, err := os.ReadDir(steamreader.statsDir())
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read stats folder: %w", err)
+	}
+
+	prefix := "UserGameStats_" + accountID + "_"
+	var result []UserGameStats
+	for _, entry := range entries {
+		appID, ok := strings.CutPrefix(entry.Name(), prefix)
+		if !ok {
+			continue
+		}
+		appID, ok = strings.CutSuffix(appID, ".bin")
+		if !ok {
+			continue
+		}
+
+		stats, err := steamreader.ReadUserGameStats(accountID, appID)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *stats)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return strings.ToLower(result[i].GameName) < strings.ToLower(result[j].GameName)
+	})
+	return result, nil
+}
+
+// statTypes maps the type values of a stats schema to StatType.
+var statTypes = map[string]StatType{
+	"1":       StatInt,
+	"int":     StatInt,
+	"2":       StatFloat,
+	"float":   StatFloat,
+	"3":       StatAvgRate,
+	"avgrate": StatAvgRate,
+}
+
+// statsDir returns the path of appcache/stats.
+func (steamreader *SteamReader) statsDir() string {
+	return filepath.Join(steamreader.steamPath, "appcache", "stats")
+}
+
+// readStatsFile parses a binary stats file and returns its single top-level
+// block, keyed by the app ID in a schema and "cache" in user stats.
+func readStatsFile(path string) (*orderedmap.OrderedMap, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := UnmarshalBinary(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	for _, key := range m.Keys() {
+		value, _ := m.Get(key)
+		if block, ok := value.(*orderedmap.OrderedMap); ok {
+			return block, nil
+		}
+	}
+	return nil, fmt.Errorf("%s has no top-level block", path)
+}
+
+// statData returns the raw 32 bits of a user stat's data value, which is
+// usually stored as an int32 whatever the stat's type, but may be a float.
+func statData(statBlock *orderedmap.OrderedMap) (uint32, error) {
+	value := vdfString(statBlock, "data")
+	if value == "" {
+		return 0, nil
+	}
+	if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return uint32(n), nil
+	}
+	f, err := strconv.ParseFloat(value, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid data %q", value)
+	}
+	return math.Float32bits(float32(f)), nil
+}
+
+// schemaAchievements parses the bits block of an achievements stat.
+func schemaAchievements(statID int, statBlock *orderedmap.OrderedMap) ([]Achievement, error) {
+	bitsVal, _ := vdfLookup(statBlock, "bits")
+	bits, _ := bitsVal.(*orderedmap.OrderedMap)
+	if bits == nil {
+		return nil, nil
+	}
+
+	var achievements []Achievement
+	for _, key := range bits.Keys() {
+		bitVal, _ := bits.Get(key)
+		bitBlock, ok := bitVal.(*orderedmap.OrderedMap)
+		if !ok {
+			continue
+		}
+
+		bit, err := manifestInt(bitBlock, "bit")
+		if err != nil {
+			return nil, err
+		}
+		if vdfString(bitBlock, "bit") == "" {
+			if bit, err = strconv.ParseInt(key, 10, 64); err != nil {
+				return nil, fmt.Errorf("invalid bit %q", key)
+			}
+		}
+
+		displayVal, _ := vdfLookup(bitBlock, "display")
+		display, _ := displayVal.(*orderedmap.OrderedMap)
+		if display == nil {
+			display = orderedmap.New()
+		}
+
+		achievements = append(achievements, Achievement{
+			Name:        vdfString(bitBlock, "name"),
+			StatID:      statID,
+			Bit:         int(bit),
+			DisplayName: localizedStrings(display, "name"),
+			Description: localizedStrings(display, "desc"),
+			Hidden:      vdfString(display, "hidden") == "1",
+			Icon:        vdfString(display, "icon"),
+			IconGray:    vdfString(display, "icon_gray"),
+		})
+	}
+	return achievements, nil
+}
+
+// localizedStrings reads a block of texts keyed by language, or a single
+// string taken as English. The "token" entry, a localization key, is skipped.
+func localizedStrings(m *orderedmap.OrderedMap, key string) map[string]string {
+	value, ok := vdfLookup(m, key)
+	if !ok {
+		return nil
+	}
+
+	switch v := value.(type) {
+	case string:
+		return map[string]string{"english": v}
+	case *orderedmap.OrderedMap:
+		texts := map[string]string{}
+		for _, language := range v.Keys() {
+			if text, ok := v.Get(language); ok && !strings.EqualFold(language, "token") {
+				if s, ok := text.(string); ok {
+					texts[strings.ToLower(language)] = s
+				}
+			}
+		}
+		return texts
+	}
+	return nil
+}
+
+// localizedText picks language from texts, falling back to English and then
+// to fallback.
+func localizedText(texts map[string]string, language, fallback string) string {
+	if text := texts[strings.ToLower(language)]; text != "" {
+		return text
+	}
+	if text := texts["english"]; text != "" {
+		return text
+	}
+	return fallback
+}
+
+// The code in this file was made by an LLM, use in production is highly discouraged as unexpected results may occur. The code in this file is not vetted for stability or edge cases.
@@ -0,0 +1,166 @@
+package steamutils
+
+import (
+	"encoding/binary"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeStatsFixture writes a stats schema for app 440 and the stats of
+// account 12345 under steamPath.
+func writeStatsFixture(t *testing.T, steamPath string) {
+	t.Helper()
+
+	schema := binaryVDFBlock("440",
+		binaryVDFStr("gamename", "Test Game"),
+		binaryVDFInt("version", 3),
+		binaryVDFBlock("stats",
+			binaryVDFBlock("1",
+				binaryVDFStr("type", "1"),
+				binaryVDFStr("name", "kills"),
+				binaryVDFBlock("display", binaryVDFStr("name", "Kills")),
+			),
+			binaryVDFBlock("2",
+				binaryVDFStr("type", "float"),
+				binaryVDFStr("name", "accuracy"),
+				binaryVDFStr("default", "0.5"),
+			),
+			binaryVDFBlock("3",
+				binaryVDFInt("type", 4),
+				binaryVDFBlock("bits",
+					binaryVDFBlock("0",
+						binaryVDFStr("name", "ACH_A"),
+						binaryVDFInt("bit", 0),
+						binaryVDFBlock("display",
+							binaryVDFBlock("name",
+								binaryVDFStr("english", "First"),
+								binaryVDFStr("German", "Erste"),
+								binaryVDFStr("token", "NEW_ACHIEVEMENT_1_0_NAME"),
+							),
+							binaryVDFBlock("desc", binaryVDFStr("english", "Do it")),
+							binaryVDFStr("hidden", "0"),
+							binaryVDFStr("icon", "a.jpg"),
+							binaryVDFStr("icon_gray", "a_gray.jpg"),
+						),
+					),
+					binaryVDFBlock("1",
+						binaryVDFStr("name", "ACH_B"),
+						binaryVDFInt("bit", 1),
+						binaryVDFBlock("display",
+							binaryVDFStr("name", "Second"),
+							binaryVDFStr("hidden", "1"),
+						),
+					),
+					// Without a "bit" value the key is the bit.
+					binaryVDFBlock("2", binaryVDFStr("name", "ACH_C")),
+				),
+			),
+		),
+	)
+
+	user := binaryVDFBlock("cache",
+		binaryVDFInt("crc", 12345),
+		binaryVDFBlock("1", binaryVDFInt("data", 42)),
+		binaryVDFBlock("2", binaryVDFInt("data", int32(math.Float32bits(0.75)))),
+		binaryVDFBlock("3",
+			binaryVDFInt("data", 0b101),
+			binaryVDFBlock("AchievementTimes",
+				binaryVDFInt("0", 1700000000),
+				binaryVDFInt("2", 1700000100),
+			),
+		),
+	)
+
+	dir := filepath.Join(steamPath, "appcache", "stats")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for name, data := range map[string][]byte{
+		"UserGameStatsSchema_440.bin":  append(schema, binaryVDFEnd),
+		"UserGameStats_12345_440.bin":  append(user, binaryVDFEnd),
+		"UserGameStatsSchema_9999.bin": binary.LittleEndian.AppendUint32(nil, 0xFFFFFFFF),
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestReadUserGameStats(t *testing.T) {
+	steamPath := makeSteamFixture(t, t.TempDir())
+	writeStatsFixture(t, steamPath)
+	reader, err := NewSteamReader(SteamReaderConfig{CustomSteamPath: steamPath, HomeDir: steamPath})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stats, err := reader.ReadUserGameStats("12345", "440")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.GameName != "Test Game" || stats.Unlocked != 2 || stats.Total != 3 {
+		t.Errorf("game %q, %d of %d unlocked; want Test Game, 2 of 3", stats.GameName, stats.Unlocked, stats.Total)
+	}
+
+	if len(stats.Stats) != 2 {
+		t.Fatalf("%d stats, want 2", len(stats.Stats))
+	}
+	kills, accuracy := stats.Stats[0], stats.Stats[1]
+	if kills.Name != "kills" || kills.DisplayName != "Kills" || kills.Type != StatInt || kills.Value != 42 {
+		t.Errorf("kills = %+v", kills)
+	}
+	if accuracy.Type != StatFloat || accuracy.Default != 0.5 || accuracy.Value != 0.75 {
+		t.Errorf("accuracy = %+v", accuracy)
+	}
+
+	if len(stats.Achievements) != 3 {
+		t.Fatalf("%d achievements, want 3", len(stats.Achievements))
+	}
+	a, b, c := stats.Achievements[0], stats.Achievements[1], stats.Achievements[2]
+	if a.Name != "ACH_A" || a.StatID != 3 || a.Bit != 0 || !a.Unlocked || !a.UnlockTime.Equal(time.Unix(1700000000, 0)) {
+		t.Errorf("ACH_A = %+v", a)
+	}
+	if a.LocalizedName("german") != "Erste" || a.LocalizedName("french") != "First" || a.LocalizedDescription("english") != "Do it" {
+		t.Errorf("ACH_A texts = %v %v", a.DisplayName, a.Description)
+	}
+	if _, ok := a.DisplayName["token"]; ok || a.Hidden || a.Icon != "a.jpg" || a.IconGray != "a_gray.jpg" {
+		t.Errorf("ACH_A display = %+v", a)
+	}
+	if b.Name != "ACH_B" || b.Unlocked || !b.UnlockTime.IsZero() || !b.Hidden || b.LocalizedName("english") != "Second" {
+		t.Errorf("ACH_B = %+v", b)
+	}
+	if c.Name != "ACH_C" || c.Bit != 2 || !c.Unlocked || !c.UnlockTime.Equal(time.Unix(1700000100, 0)) || c.LocalizedName("english") != "ACH_C" {
+		t.Errorf("ACH_C = %+v", c)
+	}
+
+	// An account without a stats file has defaults and nothing unlocked.
+	fresh, err := reader.ReadUserGameStats("67890", "440")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fresh.Unlocked != 0 || fresh.Stats[1].Value != 0.5 {
+		t.Errorf("fresh account: %d unlocked, accuracy %v; want 0 and the default", fresh.Unlocked, fresh.Stats[1].Value)
+	}
+
+	for _, ids := range [][2]string{{"12345", "abc"}, {"x", "440"}} {
+		if _, err := reader.ReadUserGameStats(ids[0], ids[1]); err == nil {
+			t.Errorf("ReadUserGameStats(%q, %q) succeeded", ids[0], ids[1])
+		}
+	}
+	if _, err := reader.ReadStatsSchema("9999"); err == nil {
+		t.Error("ReadStatsSchema succeeded on a corrupt schema")
+	}
+
+	all, err := reader.GetAllUserGameStats("12345")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 1 || all[0].AppID != "440" {
+		t.Errorf("GetAllUserGameStats = %d games, want app 440 only", len(all))
+	}
+}
+
+// The code in this file was made by an LLM, use in production is highly discouraged as unexpected results may occur. The code in this file is not vetted for stability or edge cases.
@@ -0,0 +1,39 @@
+package steamutils
+
+import (
+	"fmt"
+	"os/user"
+)
+
+// currentHomeDir returns the home directory of the user running the process.
+func currentHomeDir() (string, error) {
+	currentUser, err := user.Current()
+	if err != nil {
+		return "", fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	return currentUser.HomeDir, nil
+}
+
+// resolveHomeDir returns the home directory a reader looks up Steam in: HomeDir
+// if set, otherwise the home of UserName, otherwise the current user's.
+//
+// Each reader resolves its own home directory, so readers for different OS
+// users can be used concurrently.
+func (config SteamReaderConfig) resolveHomeDir() (string, error) {
+	if config.HomeDir != "" {
+		return config.HomeDir, nil
+	}
+
+	if config.UserName != "" {
+		lookedUp, err := user.Lookup(config.UserName)
+		if err != nil {
+			return "", fmt.Errorf("failed to look up user %s: %w", config.UserName, err)
+		}
+		return lookedUp.HomeDir, nil
+	}
+
+	return currentHomeDir()
+}
+
+// The code in this file was made by an LLM, use in production is highly discouraged as unexpected results may occur. The code in this file is not vetted for stability or edge cases.
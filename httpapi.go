@@ -0,0 +1,355 @@
+package steamutils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/iancoleman/orderedmap"
+)
+
+// HTTPHandlerOptions configures NewHTTPHandler.
+type HTTPHandlerOptions struct {
+	// PollInterval is how often the /events stream checks app manifests for
+	// changes. Defaults to two seconds.
+	PollInterval time.Duration
+}
+
+// AppEvent is a change to an installed app sent on the /events stream.
+type AppEvent struct {
+	// Type is "installed", "updated" or "uninstalled".
+	Type string `json:"type"`
+
+	// AppID is the application identifier.
+	AppID string `json:"appid"`
+
+	// App is the app's current state; nil for "uninstalled".
+	App *InstalledApp `json:"app,omitempty"`
+}
+
+// httpHandler serves the read-only JSON API over a SteamReader.
+type httpHandler struct {
+	mu                sync.Mutex
+	reader            *SteamReader
+	libraryVdfModTime time.Time
+	pollInterval      time.Duration
+	mux               *http.ServeMux
+}
+
+// NewHTTPHandler returns a read-only JSON API over reader:
+//
+//	GET /libraries          libraries from libraryfolders.vdf
+//	GET /apps               all installed apps
+//	GET /apps/{id}          one installed app
+//	GET /apps/{id}/depots   the app's installed depots
+//	GET /users              accounts from loginusers.vdf
+//	GET /events             server-sent events for installs, updates and removals
+//	GET /metrics            Prometheus metrics, see WriteMetrics
+//
+// Responses carry an ETag derived from the modification times of the files
+// they are built from, so clients can revalidate with If-None-Match.
+// libraryfolders.vdf is re-read whenever it changes on disk.
+//
+// WARNING: This function was generated by an LLM. Thoroughly test before production use.
+func NewHTTPHandler(reader *SteamReader, opts HTTPHandlerOptions) http.Handler {
+	h := &httpHandler{
+		reader:       reader,
+		pollInterval: opts.PollInterval,
+		mux:          http.NewServeMux(),
+	}
+	if h.pollInterval <= 0 {
+		h.pollInterval = 2 * time.Second
+	}
+	if info, err := os.Stat(reader.libraryVdfPath); err == nil {
+		h.libraryVdfModTime = info.ModTime()
+	}
+
+	h.mux.HandleFunc("GET /libraries", h.serveLibraries)
+	h.mux.HandleFunc("GET /apps", h.serveApps)
+	h.mux.HandleFunc("GET /apps/{id}", h.serveApp)
+	h.mux.HandleFunc("GET /apps/{id}/depots", h.serveDepots)
+	h.mux.HandleFunc("GET /users", h.serveUsers)
+	h.mux.HandleFunc("GET /events", h.serveEvents)
+	h.mux.HandleFunc("GET /metrics", h.serveMetrics)
+	return h
+}
+
+// ServeHTTP implements http.Handler.
+func (h *httpHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// withReader runs fn with exclusive use of the reader after reloading
+// libraryfolders.vdf if it changed on disk.
+func (h *httpHandler) withReader(fn func(reader *SteamReader) error) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if info, err := os.Stat(h.reader.libraryVdfPath); err == nil && !info.ModTime().Equal(h.libraryVdfModTime) {
+		if err := h.reader.reloadLibraryVdf(); err != nil {
+			return err
+		}
+		h.libraryVdfModTime = info.ModTime()
+	}
+
+	return fn(h.reader)
+}
+
+// etag hashes the name, size and modification time of libraryfolders.vdf,
+// every app manifest and the given extra files.
+func (h *httpHandler) etag(extra ...string) (string, error) {
+	var files []string
+	err := h.withReader(func(reader *SteamReader) error {
+		files = append(files, reader.libraryVdfPath)
+		libFolders, err := reader.libraryFolders()
+		if err != nil {
+			return err
+		}
+		for _, libKey := range libFolders.Keys() {
+			libraryVal, _ := libFolders.Get(libKey)
+			library, ok := libraryVal.(*orderedmap.OrderedMap)
+			if !ok {
+				continue
+			}
+			recorded := vdfString(library, "path")
+			if recorded == "" {
+				continue
+			}
+
+			// Read the directory rather than globbing, since the path may
+			// contain glob metacharacters.
+			steamapps := filepath.Join(reader.libraryPath(recorded), "steamapps")
+			entries, _ := os.ReadDir(steamapps)
+			for _, entry := range entries {
+				name := entry.Name()
+				if strings.HasPrefix(name, "appmanifest_") && strings.HasSuffix(name, ".acf") {
+					files = append(files, filepath.Join(steamapps, name))
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	files = append(files, extra...)
+
+	hash := fnv.New64a()
+	for _, file := range files {
+		fmt.Fprintf(hash, "%s\x00", file)
+		if info, err := os.Stat(file); err == nil {
+			fmt.Fprintf(hash, "%d\x00%d\x00", info.Size(), info.ModTime().UnixNano())
+		}
+	}
+	return fmt.Sprintf(`"%x"`, hash.Sum64()), nil
+}
+
+// serveJSON answers with 304 Not Modified if the client's ETag is current, and
+// otherwise writes the value produced by fn.
+func (h *httpHandler) serveJSON(w http.ResponseWriter, r *http.Request, fn func(reader *SteamReader) (interface{}, error), extra ...string) {
+	tag, err := h.etag(extra...)
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	w.Header().Set("ETag", tag)
+	if match := r.Header.Get("If-None-Match"); match != "" && etagMatches(match, tag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	var value interface{}
+	err = h.withReader(func(reader *SteamReader) error {
+		var err error
+		value, err = fn(reader)
+		return err
+	})
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(value)
+}
+
+func (h *httpHandler) serveLibraries(w http.ResponseWriter, r *http.Request) {
+	h.serveJSON(w, r, func(reader *SteamReader) (interface{}, error) {
+		return nonNil(reader.GetLibraries())
+	})
+}
+
+func (h *httpHandler) serveApps(w http.ResponseWriter, r *http.Request) {
+	h.serveJSON(w, r, func(reader *SteamReader) (interface{}, error) {
+		return nonNil(reader.GetAllInstalledApps())
+	})
+}
+
+func (h *httpHandler) serveApp(w http.ResponseWriter, r *http.Request) {
+	h.serveJSON(w, r, func(reader *SteamReader) (interface{}, error) {
+		return reader.GetInstalledAppByID(r.PathValue("id"))
+	})
+}
+
+func (h *httpHandler) serveDepots(w http.ResponseWriter, r *http.Request) {
+	h.serveJSON(w, r, func(reader *SteamReader) (interface{}, error) {
+		app, err := reader.GetInstalledAppByID(r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		return nonNil(app.InstalledDepots, nil)
+	})
+}
+
+func (h *httpHandler) serveUsers(w http.ResponseWriter, r *http.Request) {
+	h.serveJSON(w, r, func(reader *SteamReader) (interface{}, error) {
+		return nonNil(reader.GetLoginUsers())
+	}, filepath.Join(h.reader.steamPath, "config", "loginusers.vdf"))
+}
+
+func (h *httpHandler) serveMetrics(w http.ResponseWriter, r *http.Request) {
+	writeMetricsResponse(w, func(out io.Writer) error {
+		return h.withReader(func(reader *SteamReader) error {
+			return reader.WriteMetrics(out)
+		})
+	})
+}
+
+// serveEvents streams AppEvents as server-sent events, polling the app
+// manifests every PollInterval until the client disconnects.
+func (h *httpHandler) serveEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	previous, err := h.appSnapshot()
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, ": connected\n\n")
+	flusher.Flush()
+
+	ticker := time.NewTicker(h.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+		}
+
+		current, err := h.appSnapshot()
+		if err != nil {
+			// Steam may be rewriting libraryfolders.vdf; try again next tick.
+			continue
+		}
+
+		for _, event := range diffAppSnapshots(previous, current) {
+			data, _ := json.Marshal(event)
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+		}
+		flusher.Flush()
+		previous = current
+	}
+}
+
+// appSnapshot returns the installed apps keyed by AppID.
+func (h *httpHandler) appSnapshot() (map[string]InstalledApp, error) {
+	snapshot := make(map[string]InstalledApp)
+	err := h.withReader(func(reader *SteamReader) error {
+		apps, err := reader.GetAllInstalledApps()
+		if err != nil {
+			return err
+		}
+		for _, app := range apps {
+			snapshot[app.AppID] = app
+		}
+		return nil
+	})
+	return snapshot, err
+}
+
+// diffAppSnapshots lists the apps installed, updated and uninstalled between
+// two snapshots, ordered by AppID.
+func diffAppSnapshots(previous, current map[string]InstalledApp) []AppEvent {
+	var events []AppEvent
+	for appID, app := range current {
+		app := app
+		old, existed := previous[appID]
+		switch {
+		case !existed:
+			events = append(events, AppEvent{Type: "installed", AppID: appID, App: &app})
+		case old.BuildID != app.BuildID || !old.LastUpdated.Equal(app.LastUpdated) ||
+			old.SizeOnDisk != app.SizeOnDisk || old.Unavailable != app.Unavailable ||
+			old.ManifestError != app.ManifestError:
+			events = append(events, AppEvent{Type: "updated", AppID: appID, App: &app})
+		}
+	}
+	for appID := range previous {
+		if _, exists := current[appID]; !exists {
+			events = append(events, AppEvent{Type: "uninstalled", AppID: appID})
+		}
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].AppID < events[j].AppID
+	})
+	return events
+}
+
+// etagMatches reports whether an If-None-Match header lists tag.
+func etagMatches(header, tag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == tag || candidate == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// writeHTTPError writes err as a JSON error body with a matching status code.
+func writeHTTPError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, ErrLibraryUnavailable):
+		status = http.StatusServiceUnavailable
+	case errors.Is(err, ErrAppNotFound), errors.Is(err, os.ErrNotExist):
+		status = http.StatusNotFound
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
+
+// nonNil replaces a nil slice with an empty one so it encodes as [] rather
+// than null.
+func nonNil[T any](items []T, err error) (interface{}, error) {
+	if err != nil {
+		return nil, err
+	}
+	if items == nil {
+		items = []T{}
+	}
+	return items, nil
+}
+
+// The code in this file was made by an LLM, use in production is highly discouraged as unexpected results may occur. The code in this file is not vetted for stability or edge cases.
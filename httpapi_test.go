@@ -0,0 +1,53 @@
+package steamutils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestETagFollowsPathMappings(t *testing.T) {
+	root := t.TempDir()
+	steamPath := filepath.Join(root, "steam")
+	if err := os.MkdirAll(filepath.Join(steamPath, "steamapps"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeLibraryVdfFixture(t, filepath.Join(steamPath, "steamapps", "libraryfolders.vdf"), `D:\SteamLibrary`)
+
+	steamapps := filepath.Join(root, "d", "SteamLibrary", "steamapps")
+	if err := os.MkdirAll(steamapps, 0755); err != nil {
+		t.Fatal(err)
+	}
+	manifest := filepath.Join(steamapps, "appmanifest_10.acf")
+	if err := os.WriteFile(manifest, []byte("\"AppState\"\n{\n}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	reader, err := NewSteamReader(SteamReaderConfig{
+		HomeDir:         root,
+		CustomSteamPath: steamPath,
+		PathMappings:    []PathMapping{{From: `D:\`, To: filepath.Join(root, "d")}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := NewHTTPHandler(&reader, HTTPHandlerOptions{}).(*httpHandler)
+
+	before, err := h.etag()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(manifest, []byte("\"AppState\"\n{\n\t\"appid\"\t\t\"10\"\n}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	after, err := h.etag()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if before == after {
+		t.Errorf("ETag %s did not change when a manifest in a mapped library changed", before)
+	}
+}
+
+// The code in this file was made by an LLM, use in production is highly discouraged as unexpected results may occur. The code in this file is not vetted for stability or edge cases.
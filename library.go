@@ -0,0 +1,312 @@
+package steamutils
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/iancoleman/orderedmap"
+)
+
+// ErrSteamRunning is returned by operations that modify Steam's files while the
+// Steam client is running.
+var ErrSteamRunning = errors.New("Steam is running; close it before modifying its files")
+
+// ErrLibraryNotEmpty is returned when removing a library that still contains apps.
+var ErrLibraryNotEmpty = errors.New("library still contains installed apps")
+
+// LibraryApp is an entry of a library's "apps" block in libraryfolders.vdf.
+type LibraryApp struct {
+	// AppID is the application identifier.
+	AppID string
+
+	// Size is the installed size Steam recorded for the app.
+	Size int64
+}
+
+// Library represents a Steam library folder registered in libraryfolders.vdf.
+//
+// WARNING: This type was generated by an LLM. Thoroughly test before production use.
+type Library struct {
+	// Key is the library's index in libraryfolders.vdf ("0", "1", ...).
+	Key string
+
+	// Path is the library's root directory.
+	Path string
+
+	// Label is the user-visible label, often empty.
+	Label string
+
+	// ContentID identifies the library; it matches the libraryfolder.vdf marker.
+	ContentID string
+
+	// TotalSize is the size of the backing drive as recorded by Steam.
+	TotalSize int64
+
+	// Apps lists the applications Steam records as installed in this library.
+	Apps []LibraryApp
+
+	// Device classifies the storage backing the library. It is only detected
+	// on Linux and is DeviceUnknown elsewhere.
+	Device DeviceClass
+
+	// Available reports whether the library's directory can currently be
+	// reached; it is false for an SD card or USB drive that is not mounted.
+	Available bool
+}
+
+// GetLibraries returns every library folder registered in libraryfolders.vdf.
+//
+// WARNING: This method was generated by an LLM. Thoroughly test before production use.
+func (steamreader *SteamReader) GetLibraries() ([]Library, error) {
+	libFolders, err := steamreader.libraryFolders()
+	if err != nil {
+		return nil, err
+	}
+
+	var libraries []Library
+	for _, libKey := range libFolders.Keys() {
+		libraryVal, _ := libFolders.Get(libKey)
+		library, ok := libraryVal.(*orderedmap.OrderedMap)
+		if !ok {
+			continue
+		}
+
+		path := vdfString(library, "path")
+		if path == "" {
+			continue
+		}
+
+		lib := Library{
+			Key:       libKey,
+			Path:      steamreader.libraryPath(path),
+			Label:     vdfString(library, "label"),
+			ContentID: vdfString(library, "contentid"),
+		}
+		lib.TotalSize, _ = strconv.ParseInt(vdfString(library, "totalsize"), 10, 64)
+		lib.Device, lib.Available = libraryStorage(lib.Path)
+
+		if appsVal, exists := library.Get("apps"); exists {
+			if apps, ok := appsVal.(*orderedmap.OrderedMap); ok {
+				for _, appID := range apps.Keys() {
+					sizeVal, _ := apps.Get(appID)
+					sizeStr, _ := sizeVal.(string)
+					size, _ := strconv.ParseInt(sizeStr, 10, 64)
+					lib.Apps = append(lib.Apps, LibraryApp{AppID: appID, Size: size})
+				}
+			}
+		}
+
+		libraries = append(libraries, lib)
+	}
+
+	return libraries, nil
+}
+
+// AddLibrary creates a Steam library folder at path and registers it in
+// libraryfolders.vdf.
+//
+// The steamapps layout and the libraryfolder.vdf marker file are created if
+// missing. An existing marker's contentid is reused so that a library created by
+// another Steam installation keeps its identity. Refuses to run while Steam is
+// running, since Steam rewrites libraryfolders.vdf on exit.
+//
+// WARNING: This method was generated by an LLM. Thoroughly test before production use.
+func (steamreader *SteamReader) AddLibrary(path, label string) (*Library, error) {
+	if running, err := steamreader.steamRunning(); err != nil {
+		return nil, err
+	} else if running {
+		return nil, ErrSteamRunning
+	}
+
+	if !filepath.IsAbs(path) {
+		return nil, fmt.Errorf("library path %s is not absolute", path)
+	}
+	path = filepath.Clean(path)
+
+	if _, _, err := steamreader.findLibraryFolder(path); err == nil {
+		return nil, fmt.Errorf("library %s is already registered", path)
+	}
+
+	libFolders, err := steamreader.libraryFolders()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dir := range []string{
+		filepath.Join(path, "steamapps"),
+		filepath.Join(path, "steamapps", "common"),
+	} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create library layout: %w", err)
+		}
+	}
+
+	contentID, err := ensureLibraryMarker(path, label)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := orderedmap.New()
+	entry.Set("path", path)
+	entry.Set("label", label)
+	entry.Set("contentid", contentID)
+	entry.Set("totalsize", "0")
+	entry.Set("update_clean_bytes_tally", "0")
+	entry.Set("time_last_update_verified", "0")
+	entry.Set("apps", orderedmap.New())
+
+	key := nextLibraryKey(libFolders)
+	libFolders.Set(key, entry)
+	if err := steamreader.writeLibraryVdf(); err != nil {
+		libFolders.Delete(key)
+		return nil, fmt.Errorf("failed to update libraryfolders.vdf: %w", err)
+	}
+
+	return &Library{
+		Key:       key,
+		Path:      path,
+		Label:     label,
+		ContentID: contentID,
+	}, nil
+}
+
+// RemoveLibrary unregisters the library at path from libraryfolders.vdf.
+//
+// Files on disk are left untouched. Refuses to run while Steam is running, for
+// the main Steam library, and for libraries that still contain apps, either
+// according to libraryfolders.vdf or because app manifests are present.
+//
+// WARNING: This method was generated by an LLM. Thoroughly test before production use.
+func (steamreader *SteamReader) RemoveLibrary(path string) error {
+	if running, err := steamreader.steamRunning(); err != nil {
+		return err
+	} else if running {
+		return ErrSteamRunning
+	}
+
+	if samePath(path, steamreader.steamPath) {
+		return fmt.Errorf("cannot remove the main Steam library %s", path)
+	}
+
+	key, library, err := steamreader.findLibraryFolder(path)
+	if err != nil {
+		return err
+	}
+
+	if len(libraryApps(library).Keys()) > 0 {
+		return ErrLibraryNotEmpty
+	}
+
+	entries, err := os.ReadDir(filepath.Join(path, "steamapps"))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to inspect library: %w", err)
+	}
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), "appmanifest_") && strings.HasSuffix(entry.Name(), ".acf") {
+			return ErrLibraryNotEmpty
+		}
+	}
+
+	libFolders, err := steamreader.libraryFolders()
+	if err != nil {
+		return err
+	}
+
+	steamreader.libraryVdfMap.Set("libraryfolders", renumberLibraryFolders(libFolders, key))
+	if err := steamreader.writeLibraryVdf(); err != nil {
+		steamreader.libraryVdfMap.Set("libraryfolders", libFolders)
+		return fmt.Errorf("failed to update libraryfolders.vdf: %w", err)
+	}
+
+	return nil
+}
+
+// ensureLibraryMarker creates the libraryfolder.vdf marker Steam keeps at the
+// root of every library and returns its contentid.
+func ensureLibraryMarker(path, label string) (string, error) {
+	markerPath := filepath.Join(path, "libraryfolder.vdf")
+
+	if data, err := os.ReadFile(markerPath); err == nil {
+		marker, err := Unmarshal(data)
+		if err == nil {
+			if contentID := vdfString(marker, "libraryfolder", "contentid"); contentID != "" {
+				return contentID, nil
+			}
+		}
+	}
+
+	var buf [8]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return "", err
+	}
+	// Steam's content IDs are positive 63-bit integers.
+	contentID := strconv.FormatUint(binary.LittleEndian.Uint64(buf[:])>>1, 10)
+
+	inner := orderedmap.New()
+	inner.Set("contentid", contentID)
+	inner.Set("label", label)
+	marker := orderedmap.New()
+	marker.Set("libraryfolder", inner)
+
+	if err := WriteVDFFile(markerPath, marker); err != nil {
+		return "", fmt.Errorf("failed to write library marker: %w", err)
+	}
+
+	return contentID, nil
+}
+
+// nextLibraryKey returns the first unused numeric library index.
+func nextLibraryKey(libFolders *orderedmap.OrderedMap) string {
+	next := 0
+	for _, key := range libFolders.Keys() {
+		if n, err := strconv.Atoi(key); err == nil && n >= next {
+			next = n + 1
+		}
+	}
+	return strconv.Itoa(next)
+}
+
+// renumberLibraryFolders returns a copy of libFolders without removedKey whose
+// numeric keys are contiguous again, as Steam expects. Non-numeric keys such as
+// "contentstatsid" are kept as they are.
+func renumberLibraryFolders(libFolders *orderedmap.OrderedMap, removedKey string) *orderedmap.OrderedMap {
+	var numeric []int
+	for _, key := range libFolders.Keys() {
+		if key == removedKey {
+			continue
+		}
+		if n, err := strconv.Atoi(key); err == nil {
+			numeric = append(numeric, n)
+		}
+	}
+	sort.Ints(numeric)
+
+	newKeys := make(map[string]string, len(numeric))
+	for i, n := range numeric {
+		newKeys[strconv.Itoa(n)] = strconv.Itoa(i)
+	}
+
+	renumbered := orderedmap.New()
+	for _, key := range libFolders.Keys() {
+		if key == removedKey {
+			continue
+		}
+		value, _ := libFolders.Get(key)
+		if newKey, ok := newKeys[key]; ok {
+			renumbered.Set(newKey, value)
+		} else {
+			renumbered.Set(key, value)
+		}
+	}
+	return renumbered
+}
+
+// The code in this file was made by an LLM, use in production is highly discouraged as unexpected results may occur. The code in this file is not vetted for stability or edge cases.
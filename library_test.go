@@ -0,0 +1,41 @@
+package steamutils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGetLibrariesWindowsPaths(t *testing.T) {
+	steamPath := makeSteamFixture(t, t.TempDir())
+	vdf := filepath.Join(steamPath, "steamapps", "libraryfolders.vdf")
+
+	paths := []string{`\\server\share\Steam`, `C:\`, `C:\Program Files (x86)\Steam`}
+	data := "\"libraryfolders\"\n{\n\t\"contentstatsid\"\t\t\"-1\"\n"
+	for i, path := range paths {
+		data += "\t\"" + string(rune('0'+i)) + "\"\n\t{\n\t\t\"path\"\t\t\"" + escapeVDFString(path) + "\"\n\t}\n"
+	}
+	data += "}\n"
+	if err := os.WriteFile(vdf, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	reader, err := NewSteamReader(SteamReaderConfig{CustomSteamPath: steamPath, HomeDir: steamPath})
+	if err != nil {
+		t.Fatal(err)
+	}
+	libraries, err := reader.GetLibraries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(libraries) != len(paths) {
+		t.Fatalf("GetLibraries returned %d libraries, want %d", len(libraries), len(paths))
+	}
+	for i, library := range libraries {
+		if want := filepath.Clean(paths[i]); library.Path != want {
+			t.Errorf("library %s Path = %q, want %q", library.Key, library.Path, want)
+		}
+	}
+}
+
+// The code in this file was made by an LLM, use in production is highly discouraged as unexpected results may occur. The code in this file is not vetted for stability or edge cases.
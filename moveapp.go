@@ -0,0 +1,355 @@
+package steamutils
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/iancoleman/orderedmap"
+)
+
+// MoveProgress reports the state of a running MoveApp operation.
+type MoveProgress struct {
+	// Phase is one of "copy", "verify", "update" or "remove".
+	Phase string
+
+	// Path is the file currently being processed, if any.
+	Path string
+
+	// BytesDone is the number of bytes copied so far.
+	BytesDone int64
+
+	// BytesTotal is the total number of bytes to copy.
+	BytesTotal int64
+}
+
+// MoveAppOptions configures MoveApp.
+type MoveAppOptions struct {
+	// DryRun computes what would be moved without touching any files.
+	DryRun bool
+
+	// Progress, if set, is called as files are copied and at each phase change.
+	Progress func(MoveProgress)
+}
+
+// MoveItem is a file or directory that belongs to an application and is moved
+// along with it.
+type MoveItem struct {
+	// Source is the path in the current library.
+	Source string
+
+	// Destination is the path in the destination library.
+	Destination string
+
+	// Size is the total size of the item in bytes.
+	Size int64
+
+	// Files is the number of regular files and symlinks in the item.
+	Files int
+}
+
+// MoveAppResult describes a completed or planned MoveApp operation.
+type MoveAppResult struct {
+	AppID         string
+	SourceLibrary string
+	DestLibrary   string
+	Items         []MoveItem
+	TotalBytes    int64
+	DryRun        bool
+}
+
+// MoveApp moves an installed application to another registered library folder,
+// the equivalent of Steam's "Move install folder".
+//
+// The install directory, app manifest, workshop content and Proton compatdata
+// are copied to destLibrary and verified. Only then are the "apps" blocks of
+// both libraries in libraryfolders.vdf updated and the source removed. If the
+// copy or verification fails, the partial copy is removed and the source is left
+// untouched. Returns ErrSteamRunning if Steam is running and DryRun is not set.
+//
+// WARNING: This method was generated by an LLM. Thoroughly test before production use.
+func (steamreader *SteamReader) MoveApp(appID, destLibrary string, opts MoveAppOptions) (*MoveAppResult, error) {
+	app, err := steamreader.GetInstalledAppByID(appID)
+	if err != nil {
+		return nil, err
+	}
+
+	if samePath(app.LibraryPath, destLibrary) {
+		return nil, fmt.Errorf("app %s is already in library %s", appID, destLibrary)
+	}
+
+	_, sourceEntry, err := steamreader.findLibraryFolder(app.LibraryPath)
+	if err != nil {
+		return nil, err
+	}
+	_, destEntry, err := steamreader.findLibraryFolder(destLibrary)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &MoveAppResult{
+		AppID:         appID,
+		SourceLibrary: app.LibraryPath,
+		DestLibrary:   destLibrary,
+		DryRun:        opts.DryRun,
+	}
+
+	result.Items, err = appMoveItems(app, destLibrary)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range result.Items {
+		result.TotalBytes += item.Size
+		if _, err := os.Lstat(item.Destination); err == nil {
+			return nil, fmt.Errorf("destination %s already exists", item.Destination)
+		}
+	}
+
+	if opts.DryRun {
+		return result, nil
+	}
+
+	if running, err := steamreader.steamRunning(); err != nil {
+		return nil, err
+	} else if running {
+		return nil, ErrSteamRunning
+	}
+
+	progress := func(p MoveProgress) {
+		if opts.Progress != nil {
+			p.BytesTotal = result.TotalBytes
+			opts.Progress(p)
+		}
+	}
+
+	// Copy everything first so a failure never leaves the app half moved.
+	var copied []string
+	var bytesDone int64
+	for _, item := range result.Items {
+		copied = append(copied, item.Destination)
+		err = copyTree(item.Source, item.Destination, func(path string, n int64) {
+			bytesDone += n
+			progress(MoveProgress{Phase: "copy", Path: path, BytesDone: bytesDone})
+		})
+		if err != nil {
+			removeAll(copied)
+			return nil, fmt.Errorf("failed to copy %s: %w", item.Source, err)
+		}
+	}
+
+	progress(MoveProgress{Phase: "verify", BytesDone: bytesDone})
+	for _, item := range result.Items {
+		size, files, err := treeSize(item.Destination)
+		if err != nil || size != item.Size || files != item.Files {
+			removeAll(copied)
+			return nil, fmt.Errorf("verification of %s failed: copied %d bytes in %d files, expected %d bytes in %d files",
+				item.Destination, size, files, item.Size, item.Files)
+		}
+	}
+
+	progress(MoveProgress{Phase: "update", BytesDone: bytesDone})
+	moveLibraryApp(sourceEntry, destEntry, appID)
+	if err := steamreader.writeLibraryVdf(); err != nil {
+		// Put the in-memory state back so it still matches the file on disk.
+		moveLibraryApp(destEntry, sourceEntry, appID)
+		removeAll(copied)
+		return nil, fmt.Errorf("failed to update libraryfolders.vdf: %w", err)
+	}
+
+	progress(MoveProgress{Phase: "remove", BytesDone: bytesDone})
+	var sources []string
+	for _, item := range result.Items {
+		sources = append(sources, item.Source)
+	}
+	if err := removeAll(sources); err != nil {
+		return result, fmt.Errorf("app moved but the source could not be fully removed: %w", err)
+	}
+
+	return result, nil
+}
+
+// appMoveItems lists the files and directories belonging to app that exist in
+// its current library, paired with their location in destLibrary.
+func appMoveItems(app *InstalledApp, destLibrary string) ([]MoveItem, error) {
+	if app.InstallDir == "" {
+		return nil, fmt.Errorf("app %s has no installdir in its manifest", app.AppID)
+	}
+
+	relPaths := []string{
+		filepath.Join("steamapps", "common", app.InstallDir),
+		filepath.Join("steamapps", "appmanifest_"+app.AppID+".acf"),
+		filepath.Join("steamapps", "workshop", "content", app.AppID),
+		filepath.Join("steamapps", "workshop", "appworkshop_"+app.AppID+".acf"),
+		filepath.Join("steamapps", "compatdata", app.AppID),
+	}
+
+	var items []MoveItem
+	for _, rel := range relPaths {
+		source := filepath.Join(app.LibraryPath, rel)
+		if _, err := os.Lstat(source); err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+
+		size, files, err := treeSize(source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to measure %s: %w", source, err)
+		}
+
+		items = append(items, MoveItem{
+			Source:      source,
+			Destination: filepath.Join(destLibrary, rel),
+			Size:        size,
+			Files:       files,
+		})
+	}
+
+	return items, nil
+}
+
+// moveLibraryApp moves appID from the "apps" block of one libraryfolders.vdf
+// entry to another, keeping its recorded size.
+func moveLibraryApp(from, to *orderedmap.OrderedMap, appID string) {
+	fromApps := libraryApps(from)
+	size, exists := fromApps.Get(appID)
+	if !exists {
+		size = "0"
+	}
+	fromApps.Delete(appID)
+
+	toApps := libraryApps(to)
+	toApps.Set(appID, size)
+}
+
+// libraryApps returns the "apps" block of a library entry, creating it if needed.
+func libraryApps(library *orderedmap.OrderedMap) *orderedmap.OrderedMap {
+	if appsVal, exists := library.Get("apps"); exists {
+		if apps, ok := appsVal.(*orderedmap.OrderedMap); ok {
+			return apps
+		}
+	}
+
+	apps := orderedmap.New()
+	library.Set("apps", apps)
+	return apps
+}
+
+// treeSize returns the total size and the number of files and symlinks under path.
+func treeSize(path string) (size int64, files int, err error) {
+	err = filepath.WalkDir(path, func(p string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		// Count only what copyTree copies.
+		switch {
+		case info.Mode().IsRegular():
+			files++
+			size += info.Size()
+		case info.Mode()&fs.ModeSymlink != 0:
+			files++
+		}
+		return nil
+	})
+	return
+}
+
+// copyTree copies a file or directory tree, preserving permissions,
+// modification times and symlinks. onCopy is called after each file.
+func copyTree(source, destination string, onCopy func(path string, n int64)) error {
+	return filepath.WalkDir(source, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(source, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(destination, rel)
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case info.IsDir():
+			return os.MkdirAll(target, info.Mode().Perm()|0700)
+		case info.Mode()&fs.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			onCopy(path, 0)
+			return os.Symlink(link, target)
+		case info.Mode().IsRegular():
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			n, err := copyFile(path, target, info)
+			if err != nil {
+				return err
+			}
+			onCopy(path, n)
+			return nil
+		default:
+			// Sockets, devices and pipes have no place in a game install.
+			return nil
+		}
+	})
+}
+
+// copyFile copies a single regular file and applies the source's mode and
+// modification time to the copy.
+func copyFile(source, destination string, info fs.FileInfo) (int64, error) {
+	in, err := os.Open(source)
+	if err != nil {
+		return 0, err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(destination, os.O_WRONLY|os.O_CREATE|os.O_EXCL, info.Mode().Perm())
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := io.Copy(out, in)
+	if err != nil {
+		out.Close()
+		return n, err
+	}
+	if err := out.Close(); err != nil {
+		return n, err
+	}
+
+	return n, os.Chtimes(destination, info.ModTime(), info.ModTime())
+}
+
+// removeAll removes every path, returning the first error encountered.
+func removeAll(paths []string) error {
+	var firstErr error
+	for _, path := range paths {
+		if err := os.RemoveAll(path); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// The code in this file was made by an LLM, use in production is highly discouraged as unexpected results may occur. The code in this file is not vetted for stability or edge cases.
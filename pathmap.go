@@ -0,0 +1,152 @@
+package steamutils
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// PathMapping translates paths recorded by a Steam installation on another OS
+// into local paths. From is matched as a path prefix, case-insensitively for
+// Windows-style prefixes, and either separator style is accepted.
+//
+// WARNING: This type was generated by an LLM. Thoroughly test before production use.
+type PathMapping struct {
+	// From is the foreign prefix, such as `D:\` or `Z:\`.
+	From string
+
+	// To is the local directory From corresponds to, such as /mnt/d or /.
+	To string
+}
+
+// WSLPathMappings maps the drive letters A: to Z: onto their WSL mount points
+// under mountRoot (default /mnt), so D:\SteamLibrary becomes /mnt/d/SteamLibrary.
+//
+// WARNING: This function was generated by an LLM. Thoroughly test before production use.
+func WSLPathMappings(mountRoot string) []PathMapping {
+	if mountRoot == "" {
+		mountRoot = "/mnt"
+	}
+
+	var mappings []PathMapping
+	for drive := 'a'; drive <= 'z'; drive++ {
+		mappings = append(mappings, PathMapping{
+			From: strings.ToUpper(string(drive)) + `:\`,
+			To:   filepath.Join(mountRoot, string(drive)),
+		})
+	}
+	return mappings
+}
+
+// WinePathMappings maps the drive letters of the Wine or Proton prefix at
+// prefix onto the directories its dosdevices links point to, so a Steam
+// running inside the prefix can be read from the host. Without dosdevices,
+// C: maps to drive_c and Z: to the root directory, as in a default prefix.
+//
+// WARNING: This function was generated by an LLM. Thoroughly test before production use.
+func WinePathMappings(prefix string) []PathMapping {
+	dosdevices := filepath.Join(prefix, "dosdevices")
+
+	var mappings []PathMapping
+	entries, err := os.ReadDir(dosdevices)
+	if err == nil {
+		for _, entry := range entries {
+			name := entry.Name()
+			// Drives are links such as "c:"; "c::" links name raw devices.
+			if len(name) != 2 || name[1] != ':' {
+				continue
+			}
+
+			target, err := os.Readlink(filepath.Join(dosdevices, name))
+			if err != nil {
+				continue
+			}
+			if !filepath.IsAbs(target) {
+				target = filepath.Join(dosdevices, target)
+			}
+
+			mappings = append(mappings, PathMapping{
+				From: strings.ToUpper(name) + `\`,
+				To:   filepath.Clean(target),
+			})
+		}
+	}
+
+	if len(mappings) == 0 {
+		mappings = []PathMapping{
+			{From: `C:\`, To: filepath.Join(prefix, "drive_c")},
+			{From: `Z:\`, To: "/"},
+		}
+	}
+	return mappings
+}
+
+// mapRecordedPath applies the longest matching mapping to path. Paths that no
+// mapping matches are returned unchanged.
+func mapRecordedPath(path string, mappings []PathMapping) string {
+	normalized := strings.ReplaceAll(path, `\`, "/")
+
+	bestLen := -1
+	mapped := path
+	for _, mapping := range mappings {
+		from := strings.ReplaceAll(mapping.From, `\`, "/")
+		rest, ok := cutPathPrefix(normalized, from, isWindowsStylePath(mapping.From))
+		if !ok || len(from) <= bestLen {
+			continue
+		}
+
+		bestLen = len(from)
+		if rest == "" {
+			mapped = filepath.Clean(mapping.To)
+		} else {
+			mapped = filepath.Join(mapping.To, filepath.FromSlash(rest))
+		}
+	}
+	return mapped
+}
+
+// cutPathPrefix removes prefix from path if it covers whole path elements,
+// returning the remainder without a leading slash.
+func cutPathPrefix(path, prefix string, foldCase bool) (string, bool) {
+	trimmed := strings.TrimSuffix(prefix, "/")
+	if len(path) < len(trimmed) {
+		return "", false
+	}
+
+	head := path[:len(trimmed)]
+	if head != trimmed && !(foldCase && strings.EqualFold(head, trimmed)) {
+		return "", false
+	}
+
+	rest := path[len(trimmed):]
+	if rest != "" && rest[0] != '/' {
+		return "", false
+	}
+	return strings.TrimPrefix(rest, "/"), true
+}
+
+// isWindowsStylePath reports whether path has a drive letter or is a UNC path,
+// whatever OS this is built for.
+func isWindowsStylePath(path string) bool {
+	if len(path) >= 2 && path[1] == ':' {
+		c := path[0] | 0x20
+		return c >= 'a' && c <= 'z'
+	}
+	return strings.HasPrefix(path, `\\`)
+}
+
+// pathsFoldCase reports whether two paths compare case-insensitively: on
+// Windows, or when either is a Windows-style path read on another OS.
+func pathsFoldCase(a, b string) bool {
+	return runtime.GOOS == "windows" || isWindowsStylePath(a) || isWindowsStylePath(b)
+}
+
+// slashClean cleans path with forward slashes as the only separator, so
+// Windows paths compare the same on every OS.
+func slashClean(p string) string {
+	return path.Clean(strings.ReplaceAll(p, `\`, "/"))
+}
+
+// The code in this file was made by an LLM, use in production is highly discouraged as unexpected results may occur. The code in this file is not vetted for stability or edge cases.
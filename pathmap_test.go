@@ -0,0 +1,201 @@
+package steamutils
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestMapRecordedPathWSL(t *testing.T) {
+	mappings := WSLPathMappings("")
+
+	tests := []struct {
+		recorded string
+		want     string
+	}{
+		{`D:\SteamLibrary`, "/mnt/d/SteamLibrary"},
+		{`d:\SteamLibrary\steamapps`, "/mnt/d/SteamLibrary/steamapps"},
+		{`D:/SteamLibrary`, "/mnt/d/SteamLibrary"},
+		{`C:\`, "/mnt/c"},
+		{`C:`, "/mnt/c"},
+		{`C:\Program Files (x86)\Steam`, "/mnt/c/Program Files (x86)/Steam"},
+		{"/home/user/.steam/steam", "/home/user/.steam/steam"},
+		{`\\server\share\Steam`, `\\server\share\Steam`},
+	}
+
+	for _, test := range tests {
+		if got, want := mapRecordedPath(test.recorded, mappings), filepath.FromSlash(test.want); got != want {
+			t.Errorf("mapRecordedPath(%q) = %q, want %q", test.recorded, got, want)
+		}
+	}
+
+	custom := WSLPathMappings("/media")
+	if got, want := mapRecordedPath(`E:\Games`, custom), filepath.FromSlash("/media/e/Games"); got != want {
+		t.Errorf("mapRecordedPath with mount root /media = %q, want %q", got, want)
+	}
+}
+
+func TestMapRecordedPathWine(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("dosdevices symlinks need a Unix host")
+	}
+
+	prefix := t.TempDir()
+	dosdevices := filepath.Join(prefix, "dosdevices")
+	games := filepath.Join(prefix, "games")
+	for _, dir := range []string{dosdevices, filepath.Join(prefix, "drive_c"), games} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for name, target := range map[string]string{
+		"c:":  "../drive_c",
+		"d:":  games,
+		"z:":  "/",
+		"d::": "/dev/sdb1",
+	} {
+		if err := os.Symlink(target, filepath.Join(dosdevices, name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	mappings := WinePathMappings(prefix)
+	if len(mappings) != 3 {
+		t.Errorf("WinePathMappings returned %d mappings, want 3 (raw device links skipped): %v", len(mappings), mappings)
+	}
+
+	tests := []struct {
+		recorded string
+		want     string
+	}{
+		{`C:\Program Files (x86)\Steam`, filepath.Join(prefix, "drive_c", "Program Files (x86)", "Steam")},
+		{`c:\users`, filepath.Join(prefix, "drive_c", "users")},
+		{`D:\SteamLibrary`, filepath.Join(games, "SteamLibrary")},
+		{`Z:\home\user\Games`, "/home/user/Games"},
+		{`E:\Missing`, `E:\Missing`},
+	}
+	for _, test := range tests {
+		if got := mapRecordedPath(test.recorded, mappings); got != test.want {
+			t.Errorf("mapRecordedPath(%q) = %q, want %q", test.recorded, got, test.want)
+		}
+	}
+}
+
+func TestWinePathMappingsDefaultPrefix(t *testing.T) {
+	prefix := t.TempDir()
+	mappings := WinePathMappings(prefix)
+
+	tests := []struct {
+		recorded string
+		want     string
+	}{
+		{`C:\Program Files (x86)\Steam`, filepath.Join(prefix, "drive_c", "Program Files (x86)", "Steam")},
+		{`Z:\mnt\games`, filepath.FromSlash("/mnt/games")},
+	}
+	for _, test := range tests {
+		if got := mapRecordedPath(test.recorded, mappings); got != test.want {
+			t.Errorf("mapRecordedPath(%q) = %q, want %q", test.recorded, got, test.want)
+		}
+	}
+}
+
+func TestMapRecordedPathLongestPrefix(t *testing.T) {
+	mappings := []PathMapping{
+		{From: `D:\`, To: "/mnt/d"},
+		{From: `D:\SteamLibrary`, To: "/srv/steam"},
+		{From: "/old/home", To: "/home"},
+	}
+
+	tests := []struct {
+		recorded string
+		want     string
+	}{
+		{`D:\SteamLibrary\steamapps`, "/srv/steam/steamapps"},
+		{`D:\SteamLibrary2`, "/mnt/d/SteamLibrary2"},
+		{`D:\Other`, "/mnt/d/Other"},
+		{"/old/home/user", "/home/user"},
+		{"/old/homeless", "/old/homeless"},
+		{"/OLD/home/user", "/OLD/home/user"},
+	}
+	for _, test := range tests {
+		want := test.want
+		if want != test.recorded {
+			want = filepath.FromSlash(want)
+		}
+		if got := mapRecordedPath(test.recorded, mappings); got != want {
+			t.Errorf("mapRecordedPath(%q) = %q, want %q", test.recorded, got, want)
+		}
+	}
+}
+
+func TestCutPathPrefix(t *testing.T) {
+	tests := []struct {
+		path, prefix string
+		foldCase     bool
+		wantRest     string
+		wantOK       bool
+	}{
+		{"D:/SteamLibrary", "D:/", true, "SteamLibrary", true},
+		{"d:/SteamLibrary", "D:/", true, "SteamLibrary", true},
+		{"d:/SteamLibrary", "D:/", false, "", false},
+		{"D:", "D:/", true, "", true},
+		{"/mnt/data/games", "/mnt/data", false, "games", true},
+		{"/mnt/database", "/mnt/data", false, "", false},
+		{"/mnt", "/mnt/data", false, "", false},
+	}
+	for _, test := range tests {
+		rest, ok := cutPathPrefix(test.path, test.prefix, test.foldCase)
+		if rest != test.wantRest || ok != test.wantOK {
+			t.Errorf("cutPathPrefix(%q, %q, %v) = %q, %v; want %q, %v",
+				test.path, test.prefix, test.foldCase, rest, ok, test.wantRest, test.wantOK)
+		}
+	}
+}
+
+func TestIsWindowsStylePath(t *testing.T) {
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{`C:\Steam`, true},
+		{`z:/`, true},
+		{`\\server\share`, true},
+		{"/home/user", false},
+		{"relative/path", false},
+		{"1:/not-a-drive", false},
+		{"", false},
+	}
+	for _, test := range tests {
+		if got := isWindowsStylePath(test.path); got != test.want {
+			t.Errorf("isWindowsStylePath(%q) = %v, want %v", test.path, got, test.want)
+		}
+	}
+}
+
+func TestPathsFoldCase(t *testing.T) {
+	onWindows := runtime.GOOS == "windows"
+
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{`C:\Steam`, "/home/user", true},
+		{"/home/user", `D:\Games`, true},
+		{"/home/user", "/home/User", onWindows},
+	}
+	for _, test := range tests {
+		if got := pathsFoldCase(test.a, test.b); got != test.want {
+			t.Errorf("pathsFoldCase(%q, %q) = %v, want %v", test.a, test.b, got, test.want)
+		}
+	}
+
+	if !onWindows && samePath("/home/user", "/home/User") {
+		t.Error("samePath folded case for Unix paths")
+	}
+	if !samePath(`D:\SteamLibrary\`, `d:/steamlibrary`) {
+		t.Error("samePath did not match Windows paths differing in case and separators")
+	}
+}
+
+// The code in this file was made by an LLM, use in production is highly discouraged as unexpected results may occur. The code in this file is not vetted for stability or edge cases.
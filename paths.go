@@ -0,0 +1,42 @@
+package steamutils
+
+import (
+	"path/filepath"
+)
+
+// canonicalPath returns path cleaned, with symlinks resolved and, on Windows,
+// with the casing and long names stored on disk. A path that cannot be
+// resolved, for example because it does not exist, is returned cleaned.
+func canonicalPath(path string) string {
+	if path == "" {
+		return ""
+	}
+
+	cleaned := filepath.Clean(path)
+	resolved, err := filepath.EvalSymlinks(cleaned)
+	if err != nil {
+		return cleaned
+	}
+
+	return platformCanonicalPath(resolved)
+}
+
+// formatPath prepares a path for returning to callers: canonicalized if
+// FormatSteamPath is set, otherwise only cleaned.
+func (steamreader *SteamReader) formatPath(path string) string {
+	if path == "" {
+		return ""
+	}
+	if steamreader.SteamReaderConfig.FormatSteamPath {
+		return canonicalPath(path)
+	}
+	return filepath.Clean(path)
+}
+
+// libraryPath converts a library path as parsed from libraryfolders.vdf into a
+// path for this reader, applying PathMappings first.
+func (steamreader *SteamReader) libraryPath(recorded string) string {
+	return steamreader.formatPath(mapRecordedPath(recorded, steamreader.SteamReaderConfig.PathMappings))
+}
+
+// The code in this file was made by an LLM, use in production is highly discouraged as unexpected results may occur. The code in this file is not vetted for stability or edge cases.
@@ -0,0 +1,654 @@
+package steamutils
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// SaveKind describes where a group of save files lives.
+type SaveKind string
+
+// Known save kinds.
+const (
+	// SaveCloud is the Steam Cloud folder userdata/<accountid>/<appid>/remote.
+	SaveCloud SaveKind = "cloud"
+
+	// SaveAutoCloud is a Steam Auto-Cloud root outside Steam, such as the
+	// Windows AppData folder, as listed in remotecache.vdf.
+	SaveAutoCloud SaveKind = "autocloud"
+
+	// SaveProton is the Windows user profile of the app's Proton prefix,
+	// compatdata/<appid>/pfx/drive_c/users/steamuser.
+	SaveProton SaveKind = "proton"
+)
+
+// saveManifestName is the name of the SaveManifest inside a backup archive.
+const saveManifestName = "manifest.json"
+
+// SaveLocation is a directory holding save files of one app.
+//
+// WARNING: This type was generated by an LLM. Thoroughly test before production use.
+type SaveLocation struct {
+	AppID string
+
+	// AccountID is the Steam account (userdata folder) the saves belong to, or
+	// "" for SaveProton locations, which are shared by all accounts.
+	AccountID string
+
+	Kind SaveKind
+
+	// Root is the Auto-Cloud root name, such as "WinAppDataRoaming" or
+	// "GameInstall", for SaveAutoCloud locations and "" otherwise.
+	Root string
+
+	// Path is the directory on this machine.
+	Path string
+
+	// Files are the save files that exist, slash-separated and relative to Path.
+	Files []string
+}
+
+// SaveFile is one file in a save backup.
+//
+// WARNING: This type was generated by an LLM. Thoroughly test before production use.
+type SaveFile struct {
+	// Name is the file's path inside the archive.
+	Name string
+
+	AppID     string
+	AccountID string
+	Kind      SaveKind
+	Root      string
+
+	// Path is the file's slash-separated path relative to its SaveLocation.
+	Path string
+
+	Size    int64
+	ModTime time.Time
+
+	// SHA256 is the hex-encoded SHA-256 of the file contents.
+	SHA256 string
+}
+
+// SaveManifest describes the contents of a save backup archive. It is stored
+// as manifest.json, the first entry of the archive.
+//
+// WARNING: This type was generated by an LLM. Thoroughly test before production use.
+type SaveManifest struct {
+	Version int
+	Created time.Time
+	Files   []SaveFile
+}
+
+// RestoreSavesOptions controls RestoreSaves.
+//
+// WARNING: This type was generated by an LLM. Thoroughly test before production use.
+type RestoreSavesOptions struct {
+	// AccountID, if set, restores every account's saves into this account,
+	// for example when moving saves to a new Steam account.
+	AccountID string
+
+	// DryRun verifies the archive without writing any file.
+	DryRun bool
+}
+
+// FindSaves lists the save locations of the installed app appID: the Steam
+// Cloud folder and the Auto-Cloud files listed in remotecache.vdf for each
+// account that has played the app, and the user profile of the app's Proton
+// prefix, if any. Auto-Cloud files whose root cannot be located on this
+// machine are left out. Locations without files are not returned.
+//
+// WARNING: This method was generated by an LLM. Thoroughly test before production use.
+func (steamreader *SteamReader) FindSaves(appID string) ([]SaveLocation, error) {
+	app, err := steamreader.GetInstalledAppByID(appID)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts, err := steamreader.userdataAccounts()
+	if err != nil {
+		return nil, err
+	}
+
+	var locations []SaveLocation
+	for _, accountID := range accounts {
+		appDir := filepath.Join(steamreader.steamPath, "userdata", accountID, appID)
+		if _, err := os.Stat(appDir); err != nil {
+			continue
+		}
+
+		remoteDir := filepath.Join(appDir, "remote")
+		files, err := listSaveFiles(remoteDir, nil)
+		if err != nil {
+			return nil, err
+		}
+		if len(files) > 0 {
+			locations = append(locations, SaveLocation{
+				AppID: appID, AccountID: accountID, Kind: SaveCloud, Path: remoteDir, Files: files,
+			})
+		}
+
+		cache, err := readRemoteCache(filepath.Join(appDir, "remotecache.vdf"))
+		if errors.Is(err, fs.ErrNotExist) {
+			cache = &RemoteCache{}
+		} else if err != nil {
+			return nil, err
+		}
+
+		byRoot := map[string]*SaveLocation{}
+		var roots []string
+		for _, entry := range cache.Entries {
+			if entry.Root == 0 || !filepath.IsLocal(filepath.FromSlash(entry.Name)) {
+				continue
+			}
+			rootName := entry.RootName
+			dir, err := steamreader.saveRootDir(app, rootName)
+			if err != nil {
+				continue
+			}
+			if info, err := os.Stat(filepath.Join(dir, filepath.FromSlash(entry.Name))); err != nil || !info.Mode().IsRegular() {
+				continue
+			}
+
+			location, ok := byRoot[rootName]
+			if !ok {
+				location = &SaveLocation{AppID: appID, AccountID: accountID, Kind: SaveAutoCloud, Root: rootName, Path: dir}
+				byRoot[rootName] = location
+				roots = append(roots, rootName)
+			}
+			location.Files = append(location.Files, entry.Name)
+		}
+		for _, rootName := range roots {
+			locations = append(locations, *byRoot[rootName])
+		}
+	}
+
+	profile := protonUserDir(app)
+	files, err := listSaveFiles(profile, protonSaveDirs)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) > 0 {
+		locations = append(locations, SaveLocation{AppID: appID, Kind: SaveProton, Path: profile, Files: files})
+	}
+
+	return locations, nil
+}
+
+// BackupSaves writes the saves FindSaves finds for each of appIDs to w as a
+// gzip-compressed tar archive and returns its manifest.
+//
+// The archive starts with manifest.json, a SaveManifest listing every file
+// with its location, size, modification time and SHA-256, followed by the
+// files. A file reached through several locations, such as an Auto-Cloud root
+// inside the Proton prefix, is stored once, under the first.
+//
+// WARNING: This method was generated by an LLM. Thoroughly test before production use.
+func (steamreader *SteamReader) BackupSaves(w io.Writer, appIDs ...string) (*SaveManifest, error) {
+	manifest := &SaveManifest{Version: 1, Created: time.Now().UTC()}
+	var sources []string
+	seen := map[string]bool{}
+
+	// Hash everything first so the manifest can lead the archive.
+	for _, appID := range appIDs {
+		locations, err := steamreader.FindSaves(appID)
+		if err != nil {
+			return nil, fmt.Errorf("app %s: %w", appID, err)
+		}
+
+		for _, location := range locations {
+			for _, name := range location.Files {
+				source := filepath.Join(location.Path, filepath.FromSlash(name))
+				if seen[source] {
+					continue
+				}
+				seen[source] = true
+
+				file, err := hashSaveFile(source)
+				if err != nil {
+					return nil, err
+				}
+				file.AppID = location.AppID
+				file.AccountID = location.AccountID
+				file.Kind = location.Kind
+				file.Root = location.Root
+				file.Path = name
+				file.Name = path.Join(location.AppID, string(location.Kind), location.AccountID, location.Root, name)
+
+				manifest.Files = append(manifest.Files, file)
+				sources = append(sources, source)
+			}
+		}
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	err = tw.WriteHeader(&tar.Header{
+		Name:    saveManifestName,
+		Mode:    0644,
+		Size:    int64(len(manifestData)),
+		ModTime: manifest.Created,
+	})
+	if err == nil {
+		_, err = tw.Write(manifestData)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to write manifest: %w", err)
+	}
+
+	for i, file := range manifest.Files {
+		if err := writeSaveFile(tw, sources[i], file); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// RestoreSaves restores a BackupSaves archive read from r, returning its
+// manifest.
+//
+// Each file is put back into the matching location on this machine, which may
+// differ from the one it was backed up from, for example when the library has
+// moved. The apps must be installed. Nothing is written until every manifest
+// entry has a valid destination and every file matches the size and SHA-256
+// in the manifest; files are then written atomically with their original
+// modification time. Returns ErrSteamRunning if Steam is
+// running and DryRun is not set, since Steam Cloud could overwrite the
+// restored files.
+//
+// WARNING: This method was generated by an LLM. Thoroughly test before production use.
+func (steamreader *SteamReader) RestoreSaves(r io.Reader, opts RestoreSavesOptions) (*SaveManifest, error) {
+	if !opts.DryRun {
+		if running, err := steamreader.steamRunning(); err != nil {
+			return nil, err
+		} else if running {
+			return nil, ErrSteamRunning
+		}
+	}
+
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("not a save backup: %w", err)
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+
+	header, err := tr.Next()
+	if err != nil || header.Name != saveManifestName {
+		return nil, fmt.Errorf("not a save backup: %s is missing", saveManifestName)
+	}
+	var manifest SaveManifest
+	if err := json.NewDecoder(tr).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", saveManifestName, err)
+	}
+
+	if opts.AccountID != "" && !isAccountID(opts.AccountID) {
+		return nil, fmt.Errorf("invalid account ID %q", opts.AccountID)
+	}
+
+	// Resolve every destination before reading any data, so a bad entry
+	// fails the restore before anything is written.
+	apps := map[string]*InstalledApp{}
+	destinations := make([]string, len(manifest.Files))
+	pending := map[string]int{}
+	for i, file := range manifest.Files {
+		if !filepath.IsLocal(filepath.FromSlash(file.Path)) {
+			return nil, fmt.Errorf("unsafe path %q in backup", file.Path)
+		}
+		if _, err := strconv.ParseUint(file.AppID, 10, 32); err != nil {
+			return nil, fmt.Errorf("invalid app ID %q in backup", file.AppID)
+		}
+		if file.AccountID != "" && !isAccountID(file.AccountID) {
+			return nil, fmt.Errorf("invalid account ID %q in backup", file.AccountID)
+		}
+		if _, duplicate := pending[file.Name]; duplicate {
+			return nil, fmt.Errorf("backup entry %s is listed twice in the manifest", file.Name)
+		}
+		pending[file.Name] = i
+
+		app, ok := apps[file.AppID]
+		if !ok {
+			if app, err = steamreader.GetInstalledAppByID(file.AppID); err != nil {
+				return nil, fmt.Errorf("cannot restore saves of app %s: %w", file.AppID, err)
+			}
+			apps[file.AppID] = app
+		}
+
+		accountID := file.AccountID
+		if opts.AccountID != "" && accountID != "" {
+			accountID = opts.AccountID
+		}
+		dir, err := steamreader.saveLocationDir(app, accountID, file.Kind, file.Root)
+		if err != nil {
+			return nil, fmt.Errorf("cannot restore %s: %w", file.Name, err)
+		}
+		destinations[i] = filepath.Join(dir, filepath.FromSlash(file.Path))
+	}
+
+	// Verify every entry, staging the contents in a temporary directory, and
+	// only write once the whole archive checks out.
+	staging, err := os.MkdirTemp("", "steamutils-restore-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(staging)
+
+	staged := make([]string, len(manifest.Files))
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read backup: %w", err)
+		}
+
+		i, ok := pending[header.Name]
+		if !ok {
+			return nil, fmt.Errorf("backup entry %s is not in the manifest", header.Name)
+		}
+		delete(pending, header.Name)
+
+		staged[i] = filepath.Join(staging, strconv.Itoa(i))
+		if err := stageSaveFile(tr, staged[i], manifest.Files[i]); err != nil {
+			return nil, fmt.Errorf("%s: %w", header.Name, err)
+		}
+	}
+
+	if len(pending) > 0 {
+		return &manifest, fmt.Errorf("backup is incomplete: %d files listed in the manifest are missing", len(pending))
+	}
+	if opts.DryRun {
+		return &manifest, nil
+	}
+
+	for i, file := range manifest.Files {
+		data, err := os.ReadFile(staged[i])
+		if err != nil {
+			return nil, err
+		}
+
+		destination := destinations[i]
+		if err := os.MkdirAll(filepath.Dir(destination), 0755); err != nil {
+			return nil, err
+		}
+		if err := replaceFile(destination, data, nil); err != nil {
+			return nil, fmt.Errorf("failed to restore %s: %w", destination, err)
+		}
+		if !file.ModTime.IsZero() {
+			os.Chtimes(destination, file.ModTime, file.ModTime)
+		}
+	}
+	return &manifest, nil
+}
+
+// stageSaveFile copies one archive entry from r to path, checking it against
+// the size and SHA-256 of its manifest entry.
+func stageSaveFile(r io.Reader, path string, file SaveFile) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(f, h), io.LimitReader(r, file.Size+1))
+	if err != nil {
+		return fmt.Errorf("failed to read: %w", err)
+	}
+	if n != file.Size || hex.EncodeToString(h.Sum(nil)) != file.SHA256 {
+		return errors.New("does not match its manifest entry")
+	}
+	return f.Close()
+}
+
+// isAccountID reports whether id is a valid 32-bit Steam account ID, and so
+// safe to use as a userdata folder name.
+func isAccountID(id string) bool {
+	_, err := strconv.ParseUint(id, 10, 32)
+	return err == nil
+}
+
+// saveLocationDir returns the directory on this machine for a save location.
+func (steamreader *SteamReader) saveLocationDir(app *InstalledApp, accountID string, kind SaveKind, root string) (string, error) {
+	switch kind {
+	case SaveCloud:
+		if accountID == "" {
+			return "", fmt.Errorf("cloud saves need an account")
+		}
+		return filepath.Join(steamreader.steamPath, "userdata", accountID, app.AppID, "remote"), nil
+	case SaveAutoCloud:
+		return steamreader.saveRootDir(app, root)
+	case SaveProton:
+		// Creating the profile would leave Proton a prefix it cannot set up.
+		profile := protonUserDir(app)
+		if _, err := os.Stat(profile); err != nil {
+			return "", fmt.Errorf("app %s has no Proton prefix: %w", app.AppID, err)
+		}
+		return profile, nil
+	default:
+		return "", fmt.Errorf("unknown save kind %q", kind)
+	}
+}
+
+// saveRootDir locates an Auto-Cloud root for app: natively where the root
+// belongs to this OS, and inside the app's Proton prefix for Windows roots
+// elsewhere.
+func (steamreader *SteamReader) saveRootDir(app *InstalledApp, root string) (string, error) {
+	if root == "GameInstall" {
+		if app.FullPath == "" {
+			return "", fmt.Errorf("app %s has no install directory", app.AppID)
+		}
+		return app.FullPath, nil
+	}
+
+	if dir, ok := nativeSaveRoot(steamreader.homeDir, root); ok {
+		return dir, nil
+	}
+
+	if rel, ok := protonSaveRoots[root]; ok && app.LibraryPath != "" {
+		profile := protonUserDir(app)
+		if _, err := os.Stat(profile); err == nil {
+			return filepath.Join(profile, filepath.FromSlash(rel)), nil
+		}
+	}
+
+	return "", fmt.Errorf("Auto-Cloud root %s cannot be located for app %s", root, app.AppID)
+}
+
+// protonUserDir returns the Windows user profile of app's Proton prefix.
+func protonUserDir(app *InstalledApp) string {
+	return filepath.Join(app.LibraryPath, "steamapps", "compatdata", app.AppID, "pfx", "drive_c", "users", "steamuser")
+}
+
+// protonSaveRoots maps the Windows Auto-Cloud roots to their place in a
+// Proton prefix, relative to the steamuser profile.
+var protonSaveRoots = map[string]string{
+	"WinMyDocuments":     "Documents",
+	"WinAppDataLocal":    "AppData/Local",
+	"WinAppDataRoaming":  "AppData/Roaming",
+	"WinAppDataLocalLow": "AppData/LocalLow",
+	"WinSavedGames":      "Saved Games",
+	"WinProgramData":     "../../ProgramData",
+}
+
+// protonSaveDirs are the folders of a Proton profile games keep saves in.
+var protonSaveDirs = []string{
+	"Documents",
+	"AppData/Roaming",
+	"AppData/Local",
+	"AppData/LocalLow",
+	"Saved Games",
+}
+
+// remoteStorageRoots are the Auto-Cloud root names, indexed by the root
+// number remotecache.vdf stores. Root 0 is the Steam Cloud remote folder.
+var remoteStorageRoots = []string{
+	"",
+	"GameInstall",
+	"WinMyDocuments",
+	"WinAppDataLocal",
+	"WinAppDataRoaming",
+	"SteamUserBaseStorage",
+	"MacHome",
+	"MacAppSupport",
+	"MacDocuments",
+	"WinSavedGames",
+	"WinProgramData",
+	"SteamCloudDocuments",
+	"WinAppDataLocalLow",
+	"MacCaches",
+	"LinuxHome",
+	"LinuxXdgDataHome",
+	"LinuxXdgConfigHome",
+	"AndroidSteamPackageRoot",
+}
+
+// remoteStorageRootName returns the name of an Auto-Cloud root number, or
+// "Root<n>" for numbers it does not know.
+func remoteStorageRootName(root int) string {
+	if root >= 0 && root < len(remoteStorageRoots) {
+		return remoteStorageRoots[root]
+	}
+	return "Root" + strconv.Itoa(root)
+}
+
+// userdataAccounts lists the account IDs with a userdata folder.
+func (steamreader *SteamReader) userdataAccounts() ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(steamreader.steamPath, "userdata"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read userdata: %w", err)
+	}
+
+	var accounts []string
+	for _, entry := range entries {
+		if isAccountID(entry.Name()) && entry.IsDir() {
+			accounts = append(accounts, entry.Name())
+		}
+	}
+	return accounts, nil
+}
+
+// listSaveFiles lists the regular files below dir, or only below its subdirs
+// if given, as sorted slash-separated paths relative to dir. Missing
+// directories list nothing; Proton's AppData/Local/Temp is skipped.
+func listSaveFiles(dir string, subdirs []string) ([]string, error) {
+	starts := []string{dir}
+	if subdirs != nil {
+		starts = nil
+		for _, sub := range subdirs {
+			starts = append(starts, filepath.Join(dir, filepath.FromSlash(sub)))
+		}
+	}
+
+	var files []string
+	for _, start := range starts {
+		err := filepath.WalkDir(start, func(p string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				if errors.Is(err, fs.ErrNotExist) {
+					return nil
+				}
+				return err
+			}
+			rel, err := filepath.Rel(dir, p)
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() && filepath.ToSlash(rel) == "AppData/Local/Temp" {
+				return filepath.SkipDir
+			}
+			if entry.Type().IsRegular() {
+				files = append(files, filepath.ToSlash(rel))
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list saves in %s: %w", start, err)
+		}
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+// hashSaveFile returns the size, modification time and SHA-256 of a file.
+func hashSaveFile(source string) (SaveFile, error) {
+	f, err := os.Open(source)
+	if err != nil {
+		return SaveFile{}, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return SaveFile{}, err
+	}
+
+	h := sha256.New()
+	n, err := io.Copy(h, f)
+	if err != nil {
+		return SaveFile{}, fmt.Errorf("failed to hash %s: %w", source, err)
+	}
+
+	return SaveFile{Size: n, ModTime: info.ModTime().UTC(), SHA256: hex.EncodeToString(h.Sum(nil))}, nil
+}
+
+// writeSaveFile adds source to the archive as file, failing if it changed
+// since it was hashed.
+func writeSaveFile(tw *tar.Writer, source string, file SaveFile) error {
+	f, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	err = tw.WriteHeader(&tar.Header{
+		Name:    file.Name,
+		Mode:    0644,
+		Size:    file.Size,
+		ModTime: file.ModTime,
+	})
+	if err != nil {
+		return err
+	}
+
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(tw, h), io.LimitReader(f, file.Size))
+	if err != nil {
+		return fmt.Errorf("failed to archive %s: %w", source, err)
+	}
+	if n != file.Size || hex.EncodeToString(h.Sum(nil)) != file.SHA256 {
+		return fmt.Errorf("%s changed while it was being backed up", source)
+	}
+	return nil
+}
+
+// The code in this file was made by an LLM, use in production is highly discouraged as unexpected results may occur. The code in this file is not vetted for stability or edge cases.
@@ -0,0 +1,371 @@
+package steamutils
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/iancoleman/orderedmap"
+)
+
+// screenshotsAppID is the app ID Steam files screenshots under in userdata.
+const screenshotsAppID = "760"
+
+// Screenshot is a screenshot taken with the Steam overlay.
+//
+// WARNING: This type was generated by an LLM. Thoroughly test before production use.
+type Screenshot struct {
+	AccountID string
+
+	// AppID is the game the screenshot was taken in. For non-Steam games it
+	// is the shortcut's game ID.
+	AppID string
+
+	// AppName is the game's name from its installed manifest or, for
+	// non-Steam games, from screenshots.vdf; "" if unknown.
+	AppName string
+
+	// Path is the full-size image.
+	Path string
+
+	// ThumbnailPath is the thumbnail image, or "" if there is none.
+	ThumbnailPath string
+
+	Caption string
+
+	// Created is the time the screenshot was taken, or the file's
+	// modification time for screenshots missing from screenshots.vdf.
+	Created time.Time
+
+	// Width and Height are the image size recorded by Steam, 0 if unknown.
+	Width  int
+	Height int
+}
+
+// ScreenshotGroup is the screenshots of one game.
+//
+// WARNING: This type was generated by an LLM. Thoroughly test before production use.
+type ScreenshotGroup struct {
+	AppID       string
+	AppName     string
+	Screenshots []Screenshot
+}
+
+// GetScreenshots lists the screenshots of accountID, or of every account if
+// accountID is empty, oldest first.
+//
+// Entries of userdata/<accountid>/760/screenshots.vdf supply captions,
+// creation times and sizes; image files in remote/<appid>/screenshots that
+// the file does not list are included too, and entries whose image is gone
+// are left out.
+//
+// WARNING: This method was generated by an LLM. Thoroughly test before production use.
+func (steamreader *SteamReader) GetScreenshots(accountID string) ([]Screenshot, error) {
+	accounts := []string{accountID}
+	if accountID == "" {
+		var err error
+		if accounts, err = steamreader.userdataAccounts(); err != nil {
+			return nil, err
+		}
+	}
+
+	appNames := map[string]string{}
+	if apps, err := steamreader.GetAllInstalledApps(); err == nil {
+		for _, app := range apps {
+			appNames[app.AppID] = app.Name
+		}
+	}
+
+	var screenshots []Screenshot
+	for _, account := range accounts {
+		found, err := steamreader.accountScreenshots(account, appNames)
+		if err != nil {
+			return nil, err
+		}
+		screenshots = append(screenshots, found...)
+	}
+
+	sort.SliceStable(screenshots, func(i, j int) bool {
+		return screenshots[i].Created.Before(screenshots[j].Created)
+	})
+	return screenshots, nil
+}
+
+// GroupScreenshots groups screenshots by game, ordered by game name and then
+// app ID, keeping the order of the screenshots within each game.
+//
+// WARNING: This function was generated by an LLM. Thoroughly test before production use.
+func GroupScreenshots(screenshots []Screenshot) []ScreenshotGroup {
+	index := map[string]int{}
+	var groups []ScreenshotGroup
+	for _, screenshot := range screenshots {
+		i, ok := index[screenshot.AppID]
+		if !ok {
+			i = len(groups)
+			index[screenshot.AppID] = i
+			groups = append(groups, ScreenshotGroup{AppID: screenshot.AppID, AppName: screenshot.AppName})
+		}
+		groups[i].Screenshots = append(groups[i].Screenshots, screenshot)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		a, b := strings.ToLower(groups[i].AppName), strings.ToLower(groups[j].AppName)
+		if a != b {
+			return a < b
+		}
+		return groups[i].AppID < groups[j].AppID
+	})
+	return groups
+}
+
+// ExportScreenshots copies screenshots into destDir, one folder per game named
+// "<game name> (<app ID>)", or just the app ID if the name is unknown, and
+// returns them grouped as GroupScreenshots does with Path and ThumbnailPath
+// pointing at the copies. Thumbnails are copied into a thumbnails subfolder
+// only if withThumbnails is set. Copies keep the screenshot's creation time as
+// modification time.
+//
+// Screenshots of different accounts that share a file name are exported as
+// "<name> (<account ID>)<ext>". Files left in destDir by an earlier export
+// with the same size are not copied again; a file written by this export is
+// never replaced.
+//
+// WARNING: This function was generated by an LLM. Thoroughly test before production use.
+func ExportScreenshots(screenshots []Screenshot, destDir string, withThumbnails bool) ([]ScreenshotGroup, error) {
+	groups := GroupScreenshots(screenshots)
+	export := screenshotExport{written: map[string]bool{}}
+	for _, group := range groups {
+		dir := filepath.Join(destDir, screenshotFolderName(group))
+		for i, screenshot := range group.Screenshots {
+			path, err := export.file(screenshot.Path, dir, screenshot.AccountID, screenshot.Created)
+			if err != nil {
+				return nil, err
+			}
+			group.Screenshots[i].Path = path
+
+			group.Screenshots[i].ThumbnailPath = ""
+			if withThumbnails && screenshot.ThumbnailPath != "" {
+				thumbnail, err := export.file(screenshot.ThumbnailPath, filepath.Join(dir, "thumbnails"), screenshot.AccountID, screenshot.Created)
+				if err != nil {
+					return nil, err
+				}
+				group.Screenshots[i].ThumbnailPath = thumbnail
+			}
+		}
+	}
+	return groups, nil
+}
+
+// accountScreenshots lists the screenshots of one account.
+func (steamreader *SteamReader) accountScreenshots(accountID string, appNames map[string]string) ([]Screenshot, error) {
+	base := filepath.Join(steamreader.steamPath, "userdata", accountID, screenshotsAppID)
+	remote := filepath.Join(base, "remote")
+
+	recorded := map[string]Screenshot{}
+	shortcutNames := map[string]string{}
+
+	data, err := os.ReadFile(filepath.Join(base, "screenshots.vdf"))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read screenshots.vdf: %w", err)
+	}
+	if err == nil {
+		vdfMap, err := Unmarshal(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse screenshots.vdf: %w", err)
+		}
+
+		rootVal, _ := vdfLookup(vdfMap, "Screenshots")
+		root, _ := rootVal.(*orderedmap.OrderedMap)
+		if root != nil {
+			if namesVal, ok := vdfLookup(root, "shortcutnames"); ok {
+				if names, ok := namesVal.(*orderedmap.OrderedMap); ok {
+					for _, id := range names.Keys() {
+						shortcutNames[id] = vdfString(names, id)
+					}
+				}
+			}
+
+			for _, appID := range root.Keys() {
+				if strings.EqualFold(appID, "shortcutnames") {
+					continue
+				}
+				appVal, _ := root.Get(appID)
+				appBlock, ok := appVal.(*orderedmap.OrderedMap)
+				if !ok {
+					continue
+				}
+
+				for _, key := range appBlock.Keys() {
+					entryVal, _ := appBlock.Get(key)
+					entry, ok := entryVal.(*orderedmap.OrderedMap)
+					if !ok {
+						continue
+					}
+
+					screenshot, err := screenshotFromEntry(entry)
+					if err != nil {
+						return nil, fmt.Errorf("screenshots.vdf: app %s entry %s: %w", appID, key, err)
+					}
+					filename := vdfString(entry, "filename")
+					if filename == "" || !filepath.IsLocal(filepath.FromSlash(filename)) {
+						continue
+					}
+					screenshot.AccountID = accountID
+					screenshot.AppID = appID
+					screenshot.Path = filepath.Join(remote, filepath.FromSlash(filename))
+					if thumbnail := vdfString(entry, "thumbnail"); thumbnail != "" && filepath.IsLocal(filepath.FromSlash(thumbnail)) {
+						screenshot.ThumbnailPath = filepath.Join(remote, filepath.FromSlash(thumbnail))
+					}
+					recorded[screenshot.Path] = screenshot
+				}
+			}
+		}
+	}
+
+	appDirs, err := os.ReadDir(remote)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read screenshots folder: %w", err)
+	}
+
+	var screenshots []Screenshot
+	for _, appDir := range appDirs {
+		if !appDir.IsDir() {
+			continue
+		}
+		appID := appDir.Name()
+		shotsDir := filepath.Join(remote, appID, "screenshots")
+
+		files, err := os.ReadDir(shotsDir)
+		if err != nil {
+			continue
+		}
+		for _, file := range files {
+			if !file.Type().IsRegular() || !isScreenshotImage(file.Name()) {
+				continue
+			}
+			path := filepath.Join(shotsDir, file.Name())
+
+			screenshot, ok := recorded[path]
+			if !ok {
+				info, err := file.Info()
+				if err != nil {
+					continue
+				}
+				screenshot = Screenshot{AccountID: accountID, AppID: appID, Path: path, Created: info.ModTime()}
+			}
+			if screenshot.ThumbnailPath == "" {
+				screenshot.ThumbnailPath = filepath.Join(shotsDir, "thumbnails", file.Name())
+			}
+			if _, err := os.Stat(screenshot.ThumbnailPath); err != nil {
+				screenshot.ThumbnailPath = ""
+			}
+
+			screenshot.AppName = appNames[screenshot.AppID]
+			if screenshot.AppName == "" {
+				screenshot.AppName = shortcutNames[screenshot.AppID]
+			}
+			screenshots = append(screenshots, screenshot)
+		}
+	}
+	return screenshots, nil
+}
+
+// screenshotFromEntry parses the caption, creation time and size of a
+// screenshots.vdf entry.
+func screenshotFromEntry(entry *orderedmap.OrderedMap) (Screenshot, error) {
+	screenshot := Screenshot{Caption: vdfString(entry, "caption")}
+
+	var err error
+	if screenshot.Created, err = manifestTime(entry, "creation"); err != nil {
+		return Screenshot{}, err
+	}
+
+	width, err := manifestInt(entry, "width")
+	if err != nil {
+		return Screenshot{}, err
+	}
+	height, err := manifestInt(entry, "height")
+	if err != nil {
+		return Screenshot{}, err
+	}
+	screenshot.Width, screenshot.Height = int(width), int(height)
+	return screenshot, nil
+}
+
+// isScreenshotImage reports whether name is an image file Steam saves
+// screenshots as.
+func isScreenshotImage(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".jpg", ".jpeg", ".png":
+		return true
+	}
+	return false
+}
+
+// screenshotFolderName returns a folder name for a group that is valid on
+// every OS: the game name with reserved characters replaced followed by the
+// app ID, so games of the same name get separate folders, or the app ID alone.
+func screenshotFolderName(group ScreenshotGroup) string {
+	name := strings.Map(func(r rune) rune {
+		if r < 0x20 || strings.ContainsRune(`<>:"/\|?*`, r) {
+			return '_'
+		}
+		return r
+	}, group.AppName)
+	name = strings.TrimRight(strings.TrimSpace(name), ". ")
+	if name == "" {
+		return group.AppID
+	}
+	return name + " (" + group.AppID + ")"
+}
+
+// screenshotExport tracks the files one ExportScreenshots call has written.
+type screenshotExport struct {
+	written map[string]bool
+}
+
+// file copies source into dir and returns the copy's path. The copy is named
+// after source, with accountID added if this export already wrote a file of
+// that name. A file of the same name and size left by an earlier export is
+// kept instead of copied again.
+func (export screenshotExport) file(source, dir, accountID string, created time.Time) (string, error) {
+	name := filepath.Base(source)
+	destination := filepath.Join(dir, name)
+	if export.written[destination] {
+		ext := filepath.Ext(name)
+		base := strings.TrimSuffix(name, ext) + " (" + accountID + ")"
+		destination = filepath.Join(dir, base+ext)
+		for n := 2; export.written[destination]; n++ {
+			destination = filepath.Join(dir, base+" "+strconv.Itoa(n)+ext)
+		}
+	}
+	export.written[destination] = true
+
+	info, err := os.Stat(source)
+	if err != nil {
+		return "", err
+	}
+	if existing, err := os.Stat(destination); err == nil && existing.Size() == info.Size() {
+		return destination, nil
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	os.Remove(destination)
+	if _, err := copyFile(source, destination, info); err != nil {
+		return "", fmt.Errorf("failed to export %s: %w", source, err)
+	}
+	if !created.IsZero() {
+		os.Chtimes(destination, created, created)
+	}
+	return destination, nil
+}
+
+// The code in this file was made by an LLM, use in production is highly discouraged as unexpected results may occur. The code in this file is not vetted for stability or edge cases.
@@ -0,0 +1,72 @@
+package steamutils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestExportScreenshotsCollisions(t *testing.T) {
+	src := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(src, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	screenshots := []Screenshot{
+		{AccountID: "111", AppID: "100", AppName: "Game", Path: write("111/100/shot.jpg", "account 111"), Created: created},
+		{AccountID: "222", AppID: "100", AppName: "Game", Path: write("222/100/shot.jpg", "account 222"), Created: created},
+		{AccountID: "333", AppID: "100", AppName: "Game", Path: write("333/100/shot.jpg", "other size"), Created: created},
+		{AccountID: "111", AppID: "3000000000", AppName: "Game", Path: write("111/3000000000/shot.jpg", "shortcut"), Created: created},
+	}
+
+	dest := t.TempDir()
+	want := map[string]string{
+		filepath.Join(dest, "Game (100)", "shot.jpg"):        "account 111",
+		filepath.Join(dest, "Game (100)", "shot (222).jpg"):  "account 222",
+		filepath.Join(dest, "Game (100)", "shot (333).jpg"):  "other size",
+		filepath.Join(dest, "Game (3000000000)", "shot.jpg"): "shortcut",
+	}
+
+	// A second export must find the same files rather than overwrite them.
+	for run := 0; run < 2; run++ {
+		groups, err := ExportScreenshots(screenshots, dest, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		seen := map[string]bool{}
+		for _, group := range groups {
+			for _, screenshot := range group.Screenshots {
+				if seen[screenshot.Path] {
+					t.Errorf("run %d: %s returned for two screenshots", run, screenshot.Path)
+				}
+				seen[screenshot.Path] = true
+				if _, ok := want[screenshot.Path]; !ok {
+					t.Errorf("run %d: unexpected export path %s", run, screenshot.Path)
+				}
+			}
+		}
+
+		for path, content := range want {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Errorf("run %d: %v", run, err)
+				continue
+			}
+			if string(data) != content {
+				t.Errorf("run %d: %s = %q, want %q", run, path, data, content)
+			}
+		}
+	}
+}
+
+// The code in this file was made by an LLM, use in production is highly discouraged as unexpected results may occur. The code in this file is not vetted for stability or edge cases.
@@ -35,6 +35,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/iancoleman/orderedmap"
@@ -48,52 +49,102 @@ import (
 // On Windows, the registry (HKEY_CURRENT_USER\Software\Valve\Steam) is checked.
 // On Linux and macOS, standard installation paths are checked in order of likelihood.
 //
+// CustomSteamPath, if set, must contain a Steam installation, and
+// CustomLibraryVdfPath, if set, must be a readable libraryfolders.vdf; the
+// finders are only used for the ones left empty.
+//
 // Returns an error if Steam cannot be located or if libraryfolders.vdf cannot be read.
 //
 // WARNING: This function was generated by an LLM. Thoroughly test before production use.
 func NewSteamReader(steamReaderConfig SteamReaderConfig) (steamreader SteamReader, err error) {
 
-	if steamReaderConfig.UserName != "" {
-		customUser = steamReaderConfig.UserName
+	steamreader.homeDir, err = steamReaderConfig.resolveHomeDir()
+	if err != nil {
+		return
 	}
+	homeDir := steamreader.homeDir
 
 	if steamReaderConfig.LibraryVdfPathFinder == nil {
 		steamReaderConfig.LibraryVdfPathFinder = checkDefaultLibraryPath
 	}
 
-	steamReaderConfig.customSteamPathFinder = true
 	if steamReaderConfig.SteamPathFinder == nil {
-		steamReaderConfig.SteamPathFinder = GetSteamPath
-		steamReaderConfig.customSteamPathFinder = false
+		steamReaderConfig.SteamPathFinder = func() (string, error) {
+			return steamPathForHome(homeDir)
+		}
+		if steamReaderConfig.InstallationKind != "" || steamReaderConfig.InstallationPath != "" {
+			kind, path := steamReaderConfig.InstallationKind, steamReaderConfig.InstallationPath
+			steamReaderConfig.SteamPathFinder = func() (string, error) {
+				return selectSteamInstallation(homeDir, kind, path)
+			}
+		}
 	}
 
 	steamreader.SteamReaderConfig = steamReaderConfig
 
-	if steamReaderConfig.CustomSteamPath == "" {
-		steamreader.steamPath, err = steamreader.SteamReaderConfig.SteamPathFinder()
-		if err != nil {
-			return
+	steamreader.steamPath, err = steamreader.resolveSteamPath()
+	if err != nil {
+		return
+	}
+
+	err = steamreader.loadLibraryVdf()
+	return
+}
+
+// resolveSteamPath returns CustomSteamPath if it is set, failing if it does not
+// contain a Steam installation, and otherwise the path SteamPathFinder finds.
+// If the finder fails and CustomLibraryVdfPath lies in a Steam installation's
+// steamapps directory, that installation is used.
+func (steamreader *SteamReader) resolveSteamPath() (string, error) {
+	config := &steamreader.SteamReaderConfig
+
+	if config.CustomSteamPath != "" {
+		if looksLikeSteamDir(config.CustomSteamPath) {
+			return config.CustomSteamPath, nil
 		}
+		return "", fmt.Errorf("CustomSteamPath %s does not contain a Steam installation", config.CustomSteamPath)
 	}
 
-	if steamReaderConfig.CustomLibraryVdfPath == "" {
-		steamreader.libraryVdfPath, err = steamreader.SteamReaderConfig.LibraryVdfPathFinder(steamreader.steamPath)
-		if err != nil {
-			return
+	steamPath, err := config.SteamPathFinder()
+	if err == nil {
+		return steamPath, nil
+	}
+
+	if config.CustomLibraryVdfPath != "" {
+		steamappsDir := filepath.Dir(config.CustomLibraryVdfPath)
+		if strings.EqualFold(filepath.Base(steamappsDir), "steamapps") && looksLikeSteamDir(filepath.Dir(steamappsDir)) {
+			return filepath.Dir(steamappsDir), nil
 		}
 	}
 
-	libraryVdfByte, err := os.ReadFile(steamreader.libraryVdfPath)
-	if err != nil {
-		return
+	return "", err
+}
+
+// loadLibraryVdf reads CustomLibraryVdfPath, failing if it is missing or not a
+// libraryfolders.vdf, and otherwise the file LibraryVdfPathFinder finds.
+func (steamreader *SteamReader) loadLibraryVdf() error {
+	config := steamreader.SteamReaderConfig
+
+	if config.CustomLibraryVdfPath != "" {
+		steamreader.libraryVdfPath = config.CustomLibraryVdfPath
+		if err := steamreader.reloadLibraryVdf(); err != nil {
+			steamreader.libraryVdfPath = ""
+			return fmt.Errorf("CustomLibraryVdfPath %s: %w", config.CustomLibraryVdfPath, err)
+		}
+		return nil
 	}
 
-	steamreader.libraryVdfMap, err = Unmarshal(libraryVdfByte)
+	libraryVdfPath, err := config.LibraryVdfPathFinder(steamreader.steamPath)
+	if err == nil {
+		steamreader.libraryVdfPath = libraryVdfPath
+		err = steamreader.reloadLibraryVdf()
+	}
 	if err != nil {
-		return
+		steamreader.libraryVdfPath = ""
+		return err
 	}
 
-	return
+	return nil
 }
 
 // FindAppIDBuildID returns the build ID for the specified application.
@@ -108,7 +159,7 @@ func (steamreader *SteamReader) FindAppIDBuildID(AppID string) (buildId string,
 		return
 	}
 
-	f, err := os.ReadFile(dir + pathSeparator() + "steamapps" + pathSeparator() + "appmanifest_" + AppID + ".acf")
+	f, err := os.ReadFile(filepath.Join(dir, "steamapps", "appmanifest_"+AppID+".acf"))
 	if err != nil {
 		return
 	}
@@ -122,7 +173,7 @@ func (steamreader *SteamReader) FindAppIDBuildID(AppID string) (buildId string,
 		if appState, ok := appStateRaw.(*orderedmap.OrderedMap); ok {
 			buildIdInt, found := appState.Get("buildid")
 			if found {
-				buildId = buildIdInt.(string)
+				buildId, _ = buildIdInt.(string)
 			}
 
 		}
@@ -133,12 +184,12 @@ func (steamreader *SteamReader) FindAppIDBuildID(AppID string) (buildId string,
 
 func checkDefaultLibraryPath(steamPath string) (librarypath string, err error) {
 	// Use Stat instead of opening the file to avoid leaking file handles
-	_, err = os.Stat(steamPath + pathSeparator() + "steamapps" + pathSeparator() + "libraryfolders.vdf")
+	_, err = os.Stat(filepath.Join(steamPath, "steamapps", "libraryfolders.vdf"))
 	if err != nil {
 		return
 	}
 
-	librarypath = steamPath + pathSeparator() + "steamapps" + pathSeparator() + "libraryfolders.vdf"
+	librarypath = filepath.Join(steamPath, "steamapps", "libraryfolders.vdf")
 	return
 }
 
@@ -152,46 +203,29 @@ func (steamreader *SteamReader) GetLibraryVdfMap() *orderedmap.OrderedMap {
 
 // GetSteamPath returns the Steam installation directory path.
 //
-// If FormatSteamPath is enabled in SteamReaderConfig, the path is normalized
-// with proper case handling and path separators. Returns the raw path otherwise.
+// If FormatSteamPath is enabled in SteamReaderConfig, the path is canonicalized
+// (symlinks resolved, and on Windows the on-disk casing and long names used).
+// Returns the cleaned path otherwise.
 func (steamreader *SteamReader) GetSteamPath() string {
-	if !steamreader.SteamReaderConfig.customSteamPathFinder {
-		pathStrings := strings.Split(steamreader.steamPath, pathSeparator())
-		if len(pathStrings) == 0 {
-			return steamreader.steamPath
-		}
-		rebuiltPathString := strings.ToUpper(pathStrings[0]) + pathSeparator()
+	return steamreader.formatPath(steamreader.steamPath)
+}
 
-		// Handle "Program Files (x86)" case insensitively
-		if len(pathStrings) > 1 && strings.ToLower(pathStrings[1]) == "program files (x86)" {
-			rebuiltPathString += "Program Files (x86)" + pathSeparator()
+// GetHomeDir returns the home directory this reader uses to locate Steam's
+// per-user files on Linux and macOS.
+func (steamreader *SteamReader) GetHomeDir() string {
+	return steamreader.homeDir
+}
 
-			for x, y := range pathStrings {
-				if x == 0 || x == 1 {
-					continue
-				}
-				if strings.ToLower(y) == "steam" {
-					rebuiltPathString += "Steam" + pathSeparator()
-				} else {
-					rebuiltPathString += y + pathSeparator()
-				}
-			}
-		} else {
-			for x, y := range pathStrings {
-				if x == 0 {
-					continue
-				}
-				if strings.ToLower(y) == "steam" {
-					rebuiltPathString += "Steam" + pathSeparator()
-				} else {
-					rebuiltPathString += y + pathSeparator()
-				}
-			}
+// AutoLoginUser returns the Steam account that logs in automatically for this
+// reader's OS user.
+func (steamreader *SteamReader) AutoLoginUser() (string, error) {
+	return autoLoginUserForHome(steamreader.homeDir)
+}
 
-		}
-		return rebuiltPathString
-	}
-	return steamreader.steamPath
+// Registry returns the Steam registry for this reader's OS user. On Windows
+// this is always the registry of the user running the process.
+func (steamreader *SteamReader) Registry() (Registry, error) {
+	return openRegistryForHome(steamreader.homeDir)
 }
 
 // GetLibraryVdfPath returns the path to the libraryfolders.vdf configuration file.
@@ -202,21 +236,24 @@ func (steamreader *SteamReader) GetLibraryVdfPath() string {
 	return steamreader.libraryVdfPath
 }
 
+// ErrAppNotFound is returned when an app is not installed in any library.
+var ErrAppNotFound = errors.New("app not found in any library")
+
 // FindAppIDPath returns the Steam library path containing the specified application.
 //
 // It searches through all configured library folders to find the application ID.
 // Returns the library directory path where the application is installed.
-// Returns an error if the application is not found in any library.
+// Returns an error wrapping ErrAppNotFound if the application is not found in
+// any library.
 func (steamreader *SteamReader) FindAppIDPath(targetAppID string) (string, error) {
-	libFoldersVal, exists := steamreader.libraryVdfMap.Get("libraryfolders")
-	if !exists {
-		return "", fmt.Errorf("libraryfolders key not found in the VDF data")
+	libFolders, err := steamreader.libraryFolders()
+	if err != nil {
+		return "", err
 	}
 
-	libFolders, ok := libFoldersVal.(*orderedmap.OrderedMap)
-	if !ok {
-		return "", fmt.Errorf("libraryfolders is not of the expected type")
-	}
+	// An app recorded on unmounted removable media is reported only if no
+	// mounted library has it.
+	unavailablePath := ""
 
 	for _, libKey := range libFolders.Keys() {
 		libraryVal, exists := libFolders.Get(libKey)
@@ -224,19 +261,27 @@ func (steamreader *SteamReader) FindAppIDPath(targetAppID string) (string, error
 			continue
 		}
 
-		library := libraryVal.(*orderedmap.OrderedMap)
+		// Entries such as "contentstatsid" are plain values, not libraries.
+		library, ok := libraryVal.(*orderedmap.OrderedMap)
+		if !ok {
+			continue
+		}
 
-		pathVal, exists := library.Get("path")
-		if !exists {
-			return "", errors.New("libraryfolders.vdf: Path value does not exist...")
+		pathVal, _ := library.Get("path")
+		path, ok := pathVal.(string)
+		if !ok {
+			return "", fmt.Errorf("libraryfolders.vdf: library %s has no path", libKey)
 		}
 
-		path := pathVal.(string)
-		origPath := path
-		path += pathSeparator() + "steamapps"
-		directory, err := os.ReadDir(path)
+		origPath := steamreader.libraryPath(path)
+		directory, err := os.ReadDir(filepath.Join(origPath, "steamapps"))
 
 		if err != nil {
+			if _, listed := vdfLookup(library, "apps", targetAppID); listed && unavailablePath == "" {
+				if _, available := libraryStorage(origPath); !available {
+					unavailablePath = origPath
+				}
+			}
 			continue
 		}
 
@@ -260,7 +305,90 @@ func (steamreader *SteamReader) FindAppIDPath(targetAppID string) (string, error
 
 	}
 
-	return "", fmt.Errorf("app with appid %s not found in any library", targetAppID)
+	if unavailablePath != "" {
+		return "", fmt.Errorf("app with appid %s is in library %s: %w", targetAppID, unavailablePath, ErrLibraryUnavailable)
+	}
+
+	return "", fmt.Errorf("app with appid %s: %w", targetAppID, ErrAppNotFound)
+}
+
+// libraryFolders returns the "libraryfolders" block of libraryfolders.vdf.
+func (steamreader *SteamReader) libraryFolders() (*orderedmap.OrderedMap, error) {
+	libFoldersVal, exists := steamreader.libraryVdfMap.Get("libraryfolders")
+	if !exists {
+		return nil, fmt.Errorf("libraryfolders key not found in the VDF data")
+	}
+
+	libFolders, ok := libFoldersVal.(*orderedmap.OrderedMap)
+	if !ok {
+		return nil, fmt.Errorf("libraryfolders is not of the expected type")
+	}
+
+	return libFolders, nil
+}
+
+// findLibraryFolder returns the key and entry of the library whose path matches
+// libraryPath.
+func (steamreader *SteamReader) findLibraryFolder(libraryPath string) (string, *orderedmap.OrderedMap, error) {
+	libFolders, err := steamreader.libraryFolders()
+	if err != nil {
+		return "", nil, err
+	}
+
+	for _, libKey := range libFolders.Keys() {
+		libraryVal, _ := libFolders.Get(libKey)
+		library, ok := libraryVal.(*orderedmap.OrderedMap)
+		if !ok {
+			continue
+		}
+
+		pathVal, exists := library.Get("path")
+		if !exists {
+			continue
+		}
+
+		path, ok := pathVal.(string)
+		if ok && (samePath(path, libraryPath) || samePath(steamreader.libraryPath(path), libraryPath)) {
+			return libKey, library, nil
+		}
+	}
+
+	return "", nil, fmt.Errorf("library %s not found in libraryfolders.vdf", libraryPath)
+}
+
+// samePath reports whether two paths refer to the same location, ignoring
+// trailing separators and, for Windows paths, case.
+func samePath(a, b string) bool {
+	if pathsFoldCase(a, b) {
+		return strings.EqualFold(slashClean(a), slashClean(b))
+	}
+	return filepath.Clean(a) == filepath.Clean(b)
+}
+
+// writeLibraryVdf writes the in-memory library configuration back to
+// libraryfolders.vdf.
+func (steamreader *SteamReader) writeLibraryVdf() error {
+	return WriteVDFFile(steamreader.libraryVdfPath, steamreader.libraryVdfMap)
+}
+
+// reloadLibraryVdf re-reads libraryfolders.vdf, picking up libraries Steam has
+// added or removed since the reader was created.
+func (steamreader *SteamReader) reloadLibraryVdf() error {
+	data, err := os.ReadFile(steamreader.libraryVdfPath)
+	if err != nil {
+		return err
+	}
+
+	libraryVdfMap, err := Unmarshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to parse libraryfolders.vdf: %w", err)
+	}
+	if _, exists := libraryVdfMap.Get("libraryfolders"); !exists {
+		return fmt.Errorf("%s is not a libraryfolders.vdf file", steamreader.libraryVdfPath)
+	}
+
+	steamreader.libraryVdfMap = libraryVdfMap
+	return nil
 }
 
 // The code in this file was made by an LLM, use in production is highly discouraged as unexpected results may occur. The code in this file is not vetted for stability or edge cases.
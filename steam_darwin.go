@@ -4,28 +4,44 @@
 package steamutils
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
-	"os/user"
 	"path/filepath"
+	"runtime"
 	"strings"
+	"syscall"
 )
 
-// GetSteamPath finds Steam's installation path on macOS
+// GetSteamPath finds Steam's installation path on macOS for the current user
 func GetSteamPath() (string, error) {
-	// Get current user
-	currentUser, err := user.Current()
+	homeDir, err := currentHomeDir()
 	if err != nil {
-		return "", fmt.Errorf("failed to get current user: %w", err)
+		return "", err
+	}
+
+	return steamPathForHome(homeDir)
+}
+
+// steamPathForHome finds Steam's installation path under homeDir
+func steamPathForHome(homeDir string) (string, error) {
+	// The client records its own location in registry.vdf
+	if reg, err := openRegistryForHome(homeDir); err == nil {
+		if path := reg.SteamPath(); path != "" {
+			if _, err := os.Stat(filepath.Join(path, "steamapps")); err == nil {
+				return path, nil
+			}
+		}
 	}
 
 	// List of common Steam installation paths on macOS
 	steamPaths := []string{
 		// Standard Steam installation
-		filepath.Join(currentUser.HomeDir, "Library", "Application Support", "Steam"),
+		filepath.Join(homeDir, "Library", "Application Support", "Steam"),
 
 		// Legacy path
-		filepath.Join(currentUser.HomeDir, "Library", "Application Support", "steam"),
+		filepath.Join(homeDir, "Library", "Application Support", "steam"),
 
 		// Alternative installation via Homebrew Cask
 		"/Applications/Steam.app/Contents/MacOS/Steam",
@@ -43,7 +59,7 @@ func GetSteamPath() (string, error) {
 		// For .app bundle, we need to go to the data directory
 		if strings.HasSuffix(path, "Steam.app/Contents/MacOS/Steam") {
 			// Steam data is in ~/Library/Application Support/Steam
-			path = filepath.Join(currentUser.HomeDir, "Library", "Application Support", "Steam")
+			path = filepath.Join(homeDir, "Library", "Application Support", "Steam")
 		}
 
 		// Check if directory exists and contains Steam
@@ -75,13 +91,19 @@ func GetSteamPath() (string, error) {
 
 // GetAutoLoggedInSteamUsername returns Steam username on macOS
 func GetAutoLoggedInSteamUsername() (string, error) {
-	currentUser, err := user.Current()
+	homeDir, err := currentHomeDir()
 	if err != nil {
 		return "", err
 	}
 
+	return autoLoginUserForHome(homeDir)
+}
+
+// autoLoginUserForHome returns the auto-login Steam username of the OS user
+// whose home directory is homeDir
+func autoLoginUserForHome(homeDir string) (string, error) {
 	// Path to loginusers.vdf on macOS
-	loginUsersPath := filepath.Join(currentUser.HomeDir, "Library", "Application Support", "Steam", "config", "loginusers.vdf")
+	loginUsersPath := filepath.Join(homeDir, "Library", "Application Support", "Steam", "config", "loginusers.vdf")
 
 	username, err := readAutoLoginFromVDF(loginUsersPath)
 	if err != nil {
@@ -149,9 +171,127 @@ func readAutoLoginFromVDF(vdfPath string) (string, error) {
 	return "", fmt.Errorf("no user found in loginusers.vdf")
 }
 
-// pathSeparator returns the OS-specific path separator
-func pathSeparator() string {
-	return "/"
+// steamPathCandidates returns the paths DiscoverSteamInstallations checks.
+func steamPathCandidates(homeDir string) ([]installationCandidate, error) {
+	return []installationCandidate{
+		{InstallationNative, filepath.Join(homeDir, "Library", "Application Support", "Steam")},
+		{InstallationEnv, os.Getenv("STEAM_PATH")},
+	}, nil
+}
+
+// OpenRegistry returns the Steam registry of the current user, read from
+// registry.vdf in the macOS Steam data directory.
+func OpenRegistry() (Registry, error) {
+	homeDir, err := currentHomeDir()
+	if err != nil {
+		return nil, err
+	}
+
+	return openRegistryForHome(homeDir)
+}
+
+// openRegistryForHome reads the Steam registry kept under homeDir.
+func openRegistryForHome(homeDir string) (Registry, error) {
+	return ReadRegistryVdf(filepath.Join(homeDir, "Library", "Application Support", "Steam", "registry.vdf"))
+}
+
+// readSteamStatus reads the Steam client state from registry.vdf in the macOS
+// Steam data directory under homeDir.
+func readSteamStatus(homeDir string) (*SteamStatus, error) {
+	status := &SteamStatus{}
+
+	reg, err := openRegistryForHome(homeDir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return status, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	statusFromRegistry(reg, status)
+
+	// Signal 0 only checks that the process exists.
+	if status.PID > 0 {
+		err = syscall.Kill(status.PID, 0)
+		status.Running = err == nil || errors.Is(err, syscall.EPERM)
+	}
+	if !status.Running {
+		status.clearRunning()
+	}
+
+	return status, nil
+}
+
+// defaultUserSource returns the accounts ScanSystem inspects by default.
+func defaultUserSource() (UserSource, error) {
+	return DirectoryUserSource{Root: "/Users"}, nil
+}
+
+// detectPlatform reports the operating system; SteamOS and Steam Deck are
+// Linux only.
+func detectPlatform() Platform {
+	return Platform{OS: runtime.GOOS}
+}
+
+// classifyLibraryDevice is not implemented on this platform; libraries are
+// reported as unknown and mounted.
+func classifyLibraryDevice(path string) (DeviceClass, bool) {
+	return DeviceUnknown, true
+}
+
+// diskUsage returns the total and free bytes of the filesystem holding path.
+func diskUsage(path string) (total, free uint64, err error) {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(path, &st); err != nil {
+		return 0, 0, err
+	}
+	return st.Blocks * uint64(st.Bsize), st.Bavail * uint64(st.Bsize), nil
+}
+
+// platformCanonicalPath returns path unchanged; EvalSymlinks already yields
+// the on-disk form here.
+func platformCanonicalPath(path string) string {
+	return path
+}
+
+// copyFileOwner gives path the owner and group of like, for example when root
+// rewrites a file in a user's Steam directory.
+func copyFileOwner(path string, like os.FileInfo) error {
+	st, ok := like.Sys().(*syscall.Stat_t)
+	if !ok {
+		return nil
+	}
+	if int(st.Uid) == os.Getuid() && int(st.Gid) == os.Getgid() {
+		return nil
+	}
+	return os.Chown(path, int(st.Uid), int(st.Gid))
+}
+
+// syncDir flushes the directory entry changes in dir to disk, ignoring errors.
+func syncDir(dir string) {
+	f, err := os.Open(dir)
+	if err != nil {
+		return
+	}
+	f.Sync()
+	f.Close()
+}
+
+// nativeSaveRoot locates the macOS Auto-Cloud roots under homeDir.
+func nativeSaveRoot(homeDir, root string) (string, bool) {
+	if homeDir == "" {
+		return "", false
+	}
+	switch root {
+	case "MacHome":
+		return homeDir, true
+	case "MacAppSupport":
+		return filepath.Join(homeDir, "Library", "Application Support"), true
+	case "MacDocuments":
+		return filepath.Join(homeDir, "Documents"), true
+	case "MacCaches":
+		return filepath.Join(homeDir, "Library", "Caches"), true
+	}
+	return "", false
 }
 
 // The code in this file was made by an LLM, use in production is highly discouraged as unexpected results may occur. The code in this file is not vetted for stability or edge cases.
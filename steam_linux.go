@@ -4,59 +4,47 @@
 package steamutils
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
-	"os/user"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"syscall"
 )
 
-// GetSteamPath finds Steam's installation path on Linux
+// GetSteamPath finds Steam's installation path on Linux for the current user
 // Checks multiple common locations in order of likelihood
 func GetSteamPath() (string, error) {
-	// Get current user
-	var err error
-	var currentUser *user.User
-	if customUser == "" {
-		currentUser, err = user.Current()
-		if err != nil {
-			return "", fmt.Errorf("failed to get current user: %w", err)
-		}
-	} else {
-		currentUser, err = user.Lookup(customUser)
+	homeDir, err := currentHomeDir()
+	if err != nil {
+		return "", err
 	}
 
-	// List of common Steam installation paths on Linux (in order of priority)
-	steamPaths := []string{
-		// Standard Steam installation
-		filepath.Join(currentUser.HomeDir, ".steam", "steam"),
-		filepath.Join(currentUser.HomeDir, ".local", "share", "Steam"),
-
-		// Flatpak Steam
-		filepath.Join(currentUser.HomeDir, ".var", "app", "com.valvesoftware.Steam", ".steam", "steam"),
-		filepath.Join(currentUser.HomeDir, ".var", "app", "com.valvesoftware.Steam", ".local", "share", "Steam"),
-
-		// Snap Steam
-		filepath.Join(currentUser.HomeDir, "snap", "steam", "common", ".steam", "steam"),
-		filepath.Join(currentUser.HomeDir, "snap", "steam", "common", ".local", "share", "Steam"),
-
-		// Legacy paths
-		filepath.Join(currentUser.HomeDir, ".steam", "debian-installation"),
-		filepath.Join(currentUser.HomeDir, ".steam", "root"),
-
-		// System-wide installation (uncommon but possible)
-		"/usr/share/steam",
-		"/usr/local/share/steam",
+	return steamPathForHome(homeDir)
+}
 
-		// Check if STEAM_PATH environment variable is set
-		os.Getenv("STEAM_PATH"),
+// steamPathForHome finds Steam's installation path under homeDir
+func steamPathForHome(homeDir string) (string, error) {
+	// The running client records its own location in registry.vdf, which beats
+	// guessing from the usual install locations.
+	if reg, err := openRegistryForHome(homeDir); err == nil {
+		if path := reg.SteamPath(); path != "" && looksLikeSteamDir(path) {
+			return path, nil
+		}
 	}
 
+	candidates := linuxSteamPathCandidates(homeDir)
+
 	// Try each path
-	for _, path := range steamPaths {
+	var steamPaths []string
+	for _, candidate := range candidates {
+		path := candidate.path
 		if path == "" {
 			continue
 		}
+		steamPaths = append(steamPaths, path)
 
 		// Follow symlinks
 		resolvedPath, err := filepath.EvalSymlinks(path)
@@ -65,91 +53,114 @@ func GetSteamPath() (string, error) {
 			resolvedPath = path
 		}
 
-		// Check if directory exists and contains Steam
-		if _, err := os.Stat(filepath.Join(resolvedPath, "steamapps")); err == nil {
+		if looksLikeSteamDir(resolvedPath) {
 			return resolvedPath, nil
 		}
+	}
 
-		// Some installations might have steamapps as a symlink
-		if _, err := os.Stat(resolvedPath); err == nil {
-			// Check if this looks like a Steam directory
-			entries, err := os.ReadDir(resolvedPath)
-			if err == nil {
-				hasSteamFiles := false
-				for _, entry := range entries {
-					name := entry.Name()
-					if name == "steamapps" || name == "userdata" || name == "config" {
-						hasSteamFiles = true
-						break
-					}
-				}
-				if hasSteamFiles {
-					return resolvedPath, nil
-				}
-			}
-		}
+	return "", fmt.Errorf("Steam installation not found. Searched paths: %v", steamPaths)
+}
+
+// linuxSteamPathCandidates lists the common Steam installation paths on Linux
+// in order of priority.
+func linuxSteamPathCandidates(homeDir string) []installationCandidate {
+	return []installationCandidate{
+		// Standard Steam installation
+		{InstallationNative, filepath.Join(homeDir, ".steam", "steam")},
+		{InstallationNative, filepath.Join(homeDir, ".local", "share", "Steam")},
+
+		// Flatpak Steam
+		{InstallationFlatpak, filepath.Join(homeDir, ".var", "app", "com.valvesoftware.Steam", ".steam", "steam")},
+		{InstallationFlatpak, filepath.Join(homeDir, ".var", "app", "com.valvesoftware.Steam", ".local", "share", "Steam")},
+
+		// Snap Steam
+		{InstallationSnap, filepath.Join(homeDir, "snap", "steam", "common", ".steam", "steam")},
+		{InstallationSnap, filepath.Join(homeDir, "snap", "steam", "common", ".local", "share", "Steam")},
+
+		// Legacy paths
+		{InstallationNative, filepath.Join(homeDir, ".steam", "debian-installation")},
+		{InstallationNative, filepath.Join(homeDir, ".steam", "root")},
+
+		// System-wide installation (uncommon but possible)
+		{InstallationSystem, "/usr/share/steam"},
+		{InstallationSystem, "/usr/local/share/steam"},
+
+		// Check if STEAM_PATH environment variable is set
+		{InstallationEnv, os.Getenv("STEAM_PATH")},
 	}
+}
 
-	// If no standard path found, try to find via registry file (Linux has a registry.vdf)
-	registryPath := filepath.Join(currentUser.HomeDir, ".steam", "registry.vdf")
-	if steamPath, err := findSteamPathFromRegistry(registryPath); err == nil && steamPath != "" {
-		return steamPath, nil
+// steamPathCandidates returns the paths DiscoverSteamInstallations checks.
+func steamPathCandidates(homeDir string) ([]installationCandidate, error) {
+	candidates := linuxSteamPathCandidates(homeDir)
+
+	// A path recorded by the client that is not in the usual places, such as a
+	// custom Valve package prefix.
+	if reg, err := openRegistryForHome(homeDir); err == nil && reg.SteamPath() != "" {
+		candidates = append(candidates, installationCandidate{InstallationNative, reg.SteamPath()})
 	}
 
-	return "", fmt.Errorf("Steam installation not found. Searched paths: %v", steamPaths)
+	return candidates, nil
 }
 
-// findSteamPathFromRegistry attempts to read Steam path from registry.vdf on Linux
-func findSteamPathFromRegistry(registryPath string) (string, error) {
-	data, err := os.ReadFile(registryPath)
+// OpenRegistry returns the Steam registry of the current user, read from
+// ~/.steam/registry.vdf or its Flatpak equivalent.
+func OpenRegistry() (Registry, error) {
+	homeDir, err := currentHomeDir()
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	// Parse the VDF file to find SteamPath or InstallPath
-	lines := strings.Split(string(data), "\n")
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
+	return openRegistryForHome(homeDir)
+}
 
-		// Look for InstallPath or SteamPath entries
-		if strings.Contains(line, "InstallPath") || strings.Contains(line, "SteamPath") {
-			// Extract the path between quotes
-			parts := strings.Split(line, "\"")
-			if len(parts) >= 4 {
-				path := parts[3]
-				if _, err := os.Stat(path); err == nil {
-					return path, nil
-				}
-			}
+// openRegistryForHome reads the Steam registry kept under homeDir.
+func openRegistryForHome(homeDir string) (Registry, error) {
+	registryPaths := []string{
+		filepath.Join(homeDir, ".steam", "registry.vdf"),
+		filepath.Join(homeDir, ".var", "app", "com.valvesoftware.Steam", ".steam", "registry.vdf"),
+	}
+
+	for _, path := range registryPaths {
+		reg, err := ReadRegistryVdf(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
 		}
+		return reg, err
 	}
 
-	return "", fmt.Errorf("Steam path not found in registry.vdf")
+	return nil, fmt.Errorf("registry.vdf not found. Searched paths: %v", registryPaths)
 }
 
 // GetAutoLoggedInSteamUsername returns Steam username on Linux
 // This is less reliable than Windows but attempts to find it
 func GetAutoLoggedInSteamUsername() (string, error) {
-	// Get current user
-	var err error
-	var currentUser *user.User
-	if customUser == "" {
-		currentUser, err = user.Current()
-		if err != nil {
-			return "", fmt.Errorf("failed to get current user: %w", err)
+	homeDir, err := currentHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return autoLoginUserForHome(homeDir)
+}
+
+// autoLoginUserForHome returns the auto-login Steam username of the OS user
+// whose home directory is homeDir
+func autoLoginUserForHome(homeDir string) (string, error) {
+	// Steam records the remembered account in registry.vdf
+	if reg, err := openRegistryForHome(homeDir); err == nil {
+		if username := reg.AutoLoginUser(); username != "" {
+			return username, nil
 		}
-	} else {
-		currentUser, err = user.Lookup(customUser)
 	}
 
 	// Try to read from loginusers.vdf
-	loginUsersPath := filepath.Join(currentUser.HomeDir, ".local", "share", "Steam", "config", "loginusers.vdf")
+	loginUsersPath := filepath.Join(homeDir, ".local", "share", "Steam", "config", "loginusers.vdf")
 
 	// Try alternate paths
 	alternatePaths := []string{
 		loginUsersPath,
-		filepath.Join(currentUser.HomeDir, ".steam", "steam", "config", "loginusers.vdf"),
-		filepath.Join(currentUser.HomeDir, ".var", "app", "com.valvesoftware.Steam", ".local", "share", "Steam", "config", "loginusers.vdf"),
+		filepath.Join(homeDir, ".steam", "steam", "config", "loginusers.vdf"),
+		filepath.Join(homeDir, ".var", "app", "com.valvesoftware.Steam", ".local", "share", "Steam", "config", "loginusers.vdf"),
 	}
 
 	for _, path := range alternatePaths {
@@ -220,9 +231,267 @@ func readAutoLoginFromVDF(vdfPath string) (string, error) {
 	return "", fmt.Errorf("no user found in loginusers.vdf")
 }
 
-// pathSeparator returns the OS-specific path separator
-func pathSeparator() string {
-	return "/"
+// readSteamStatus reads the Steam client state from ~/.steam/steam.pid and
+// ~/.steam/registry.vdf under homeDir.
+func readSteamStatus(homeDir string) (*SteamStatus, error) {
+	status := &SteamStatus{}
+
+	data, err := os.ReadFile(filepath.Join(homeDir, ".steam", "steam.pid"))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	if err == nil {
+		status.PID, _ = strconv.Atoi(strings.TrimSpace(string(data)))
+	}
+
+	if reg, err := openRegistryForHome(homeDir); err == nil {
+		statusFromRegistry(reg, status)
+	}
+
+	// Steam leaves both the pid file and registry entries behind on a crash,
+	// so confirm the process is alive.
+	status.Running = status.PID > 0 && processAlive(status.PID)
+	if !status.Running {
+		status.clearRunning()
+	}
+
+	return status, nil
+}
+
+// processAlive reports whether pid belongs to a running Steam process.
+func processAlive(pid int) bool {
+	comm, err := os.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "comm"))
+	if err != nil {
+		return false
+	}
+
+	// The client process is "steam"; pid reuse by another program is possible.
+	name := strings.ToLower(strings.TrimSpace(string(comm)))
+	return strings.Contains(name, "steam")
+}
+
+// defaultUserSource returns the accounts ScanSystem inspects by default.
+func defaultUserSource() (UserSource, error) {
+	return PasswdUserSource{Path: "/etc/passwd"}, nil
+}
+
+// detectPlatform identifies SteamOS from /etc/os-release and Steam Deck
+// hardware from the DMI board name.
+func detectPlatform() Platform {
+	platform := Platform{OS: "linux"}
+
+	if data, err := os.ReadFile("/etc/os-release"); err == nil {
+		for _, line := range strings.Split(string(data), "\n") {
+			key, value, found := strings.Cut(strings.TrimSpace(line), "=")
+			if !found {
+				continue
+			}
+			value = strings.Trim(value, `"'`)
+			if (key == "ID" && value == "steamos") || (key == "ID_LIKE" && strings.Contains(value, "steamos")) {
+				platform.SteamOS = true
+			}
+		}
+	}
+
+	vendor, _ := os.ReadFile("/sys/class/dmi/id/sys_vendor")
+	board, _ := os.ReadFile("/sys/class/dmi/id/board_name")
+	if strings.TrimSpace(string(vendor)) == "Valve" {
+		switch model := strings.TrimSpace(string(board)); model {
+		case "Jupiter", "Galileo":
+			platform.SteamDeck = true
+			platform.DeviceModel = model
+		}
+	}
+
+	return platform
+}
+
+// mountEntry is a line of /proc/mounts.
+type mountEntry struct {
+	device     string
+	mountPoint string
+	fsType     string
+}
+
+// networkFilesystems lists the filesystem types treated as network storage.
+var networkFilesystems = map[string]bool{
+	"nfs": true, "nfs4": true, "cifs": true, "smb3": true, "smbfs": true,
+	"9p": true, "afs": true, "ceph": true, "glusterfs": true, "fuse.sshfs": true,
+	"fuse.rclone": true, "davfs": true,
+}
+
+// readMounts parses /proc/mounts.
+func readMounts() ([]mountEntry, error) {
+	data, err := os.ReadFile("/proc/mounts")
+	if err != nil {
+		return nil, err
+	}
+
+	var mounts []mountEntry
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			continue
+		}
+		mounts = append(mounts, mountEntry{
+			device:     unescapeMountField(fields[0]),
+			mountPoint: unescapeMountField(fields[1]),
+			fsType:     fields[2],
+		})
+	}
+	return mounts, nil
+}
+
+// unescapeMountField decodes the octal escapes (\040 for space) used in
+// /proc/mounts.
+func unescapeMountField(s string) string {
+	if !strings.Contains(s, "\\") {
+		return s
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+3 < len(s) {
+			if n, err := strconv.ParseUint(s[i+1:i+4], 8, 8); err == nil {
+				sb.WriteByte(byte(n))
+				i += 3
+				continue
+			}
+		}
+		sb.WriteByte(s[i])
+	}
+	return sb.String()
+}
+
+// isRemovableMediaPath reports whether path lives where udisks mounts
+// removable media, as SteamOS does for SD cards.
+func isRemovableMediaPath(path string) bool {
+	return strings.HasPrefix(path, "/run/media/") || strings.HasPrefix(path, "/media/")
+}
+
+// classifyLibraryDevice finds the mount backing path in /proc/mounts and
+// classifies its device using /sys/block. A library under a removable-media
+// directory that is only covered by the root or /run mount is unmounted.
+func classifyLibraryDevice(path string) (DeviceClass, bool) {
+	mounts, err := readMounts()
+	if err != nil {
+		return DeviceUnknown, true
+	}
+
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		resolved = filepath.Clean(path)
+	}
+
+	var best mountEntry
+	for _, m := range mounts {
+		if resolved == m.mountPoint || strings.HasPrefix(resolved, strings.TrimSuffix(m.mountPoint, "/")+"/") {
+			if len(m.mountPoint) > len(best.mountPoint) {
+				best = m
+			}
+		}
+	}
+
+	if isRemovableMediaPath(resolved) && !isRemovableMediaPath(best.mountPoint) {
+		return DeviceRemovable, false
+	}
+
+	if networkFilesystems[best.fsType] {
+		return DeviceNetwork, true
+	}
+
+	if !strings.HasPrefix(best.device, "/dev/") {
+		return DeviceUnknown, true
+	}
+
+	if blockDeviceRemovable(best.device) || isRemovableMediaPath(best.mountPoint) {
+		return DeviceRemovable, true
+	}
+	return DeviceInternal, true
+}
+
+// blockDeviceRemovable reports whether the disk holding a /dev partition is
+// removable: flagged so in sysfs, attached over USB, or an SD card.
+func blockDeviceRemovable(device string) bool {
+	resolved, err := filepath.EvalSymlinks(device)
+	if err != nil {
+		resolved = device
+	}
+
+	// /sys/class/block/<partition> links into the parent disk's directory.
+	sysPath, err := filepath.EvalSymlinks(filepath.Join("/sys/class/block", filepath.Base(resolved)))
+	if err != nil {
+		return false
+	}
+	if _, err := os.Stat(filepath.Join(sysPath, "partition")); err == nil {
+		sysPath = filepath.Dir(sysPath)
+	}
+
+	if removable, err := os.ReadFile(filepath.Join(sysPath, "removable")); err == nil && strings.TrimSpace(string(removable)) == "1" {
+		return true
+	}
+	if strings.Contains(sysPath, "/usb") {
+		return true
+	}
+	if cardType, err := os.ReadFile(filepath.Join(sysPath, "device", "type")); err == nil && strings.TrimSpace(string(cardType)) == "SD" {
+		return true
+	}
+	return false
+}
+
+// diskUsage returns the total and free bytes of the filesystem holding path.
+func diskUsage(path string) (total, free uint64, err error) {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(path, &st); err != nil {
+		return 0, 0, err
+	}
+	return st.Blocks * uint64(st.Bsize), st.Bavail * uint64(st.Bsize), nil
+}
+
+// platformCanonicalPath returns path unchanged; EvalSymlinks already yields
+// the on-disk form here.
+func platformCanonicalPath(path string) string {
+	return path
+}
+
+// copyFileOwner gives path the owner and group of like, for example when root
+// rewrites a file in a user's Steam directory.
+func copyFileOwner(path string, like os.FileInfo) error {
+	st, ok := like.Sys().(*syscall.Stat_t)
+	if !ok {
+		return nil
+	}
+	if int(st.Uid) == os.Getuid() && int(st.Gid) == os.Getgid() {
+		return nil
+	}
+	return os.Chown(path, int(st.Uid), int(st.Gid))
+}
+
+// syncDir flushes the directory entry changes in dir to disk, ignoring errors.
+func syncDir(dir string) {
+	f, err := os.Open(dir)
+	if err != nil {
+		return
+	}
+	f.Sync()
+	f.Close()
+}
+
+// nativeSaveRoot locates the Linux Auto-Cloud roots under homeDir; Windows
+// roots are looked up in the app's Proton prefix instead.
+func nativeSaveRoot(homeDir, root string) (string, bool) {
+	if homeDir == "" {
+		return "", false
+	}
+	switch root {
+	case "LinuxHome":
+		return homeDir, true
+	case "LinuxXdgDataHome":
+		return filepath.Join(homeDir, ".local", "share"), true
+	case "LinuxXdgConfigHome":
+		return filepath.Join(homeDir, ".config"), true
+	}
+	return "", false
 }
 
 // The code in this file was made by an LLM, use in production is highly discouraged as unexpected results may occur. The code in this file is not vetted for stability or edge cases.
@@ -0,0 +1,208 @@
+package steamutils
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// makeSteamFixture creates a Steam tree under dir with a libraryfolders.vdf
+// listing the tree itself, and returns dir.
+func makeSteamFixture(t *testing.T, dir string) string {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Join(dir, "steamapps"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeLibraryVdfFixture(t, filepath.Join(dir, "steamapps", "libraryfolders.vdf"), dir)
+	return dir
+}
+
+// writeLibraryVdfFixture writes a libraryfolders.vdf with one library at
+// libraryPath.
+func writeLibraryVdfFixture(t *testing.T, path, libraryPath string) {
+	t.Helper()
+	data := "\"libraryfolders\"\n{\n\t\"contentstatsid\"\t\t\"-1\"\n\t\"0\"\n\t{\n\t\t\"path\"\t\t\"" +
+		escapeVDFString(libraryPath) + "\"\n\t}\n}\n"
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNewSteamReaderPathResolution(t *testing.T) {
+	root := t.TempDir()
+	custom := makeSteamFixture(t, filepath.Join(root, "custom"))
+	detected := makeSteamFixture(t, filepath.Join(root, "detected"))
+
+	notSteam := filepath.Join(root, "not-steam")
+	if err := os.MkdirAll(notSteam, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// A Steam tree without its own libraryfolders.vdf.
+	bare := filepath.Join(root, "bare")
+	if err := os.MkdirAll(filepath.Join(bare, "steamapps"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	external := filepath.Join(root, "external.vdf")
+	writeLibraryVdfFixture(t, external, custom)
+
+	notLibraryVdf := filepath.Join(root, "config.vdf")
+	if err := os.WriteFile(notLibraryVdf, []byte("\"InstallConfigStore\"\n{\n}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	findDetected := func() (string, error) { return detected, nil }
+	findNothing := func() (string, error) { return "", errors.New("no Steam installation found") }
+
+	tests := []struct {
+		name           string
+		config         SteamReaderConfig
+		wantSteamPath  string
+		wantLibraryVdf string
+		wantErr        bool
+	}{
+		{
+			name:           "finder only",
+			config:         SteamReaderConfig{SteamPathFinder: findDetected},
+			wantSteamPath:  detected,
+			wantLibraryVdf: filepath.Join(detected, "steamapps", "libraryfolders.vdf"),
+		},
+		{
+			name:    "finder fails",
+			config:  SteamReaderConfig{SteamPathFinder: findNothing},
+			wantErr: true,
+		},
+		{
+			name:           "custom steam path wins over finder",
+			config:         SteamReaderConfig{SteamPathFinder: findDetected, CustomSteamPath: custom},
+			wantSteamPath:  custom,
+			wantLibraryVdf: filepath.Join(custom, "steamapps", "libraryfolders.vdf"),
+		},
+		{
+			name:           "custom steam path without finder",
+			config:         SteamReaderConfig{SteamPathFinder: findNothing, CustomSteamPath: custom},
+			wantSteamPath:  custom,
+			wantLibraryVdf: filepath.Join(custom, "steamapps", "libraryfolders.vdf"),
+		},
+		{
+			name:    "invalid custom steam path is not replaced by finder",
+			config:  SteamReaderConfig{SteamPathFinder: findDetected, CustomSteamPath: notSteam},
+			wantErr: true,
+		},
+		{
+			name:    "missing custom steam path",
+			config:  SteamReaderConfig{SteamPathFinder: findDetected, CustomSteamPath: filepath.Join(root, "missing")},
+			wantErr: true,
+		},
+		{
+			name:           "custom library vdf outside the steam tree",
+			config:         SteamReaderConfig{SteamPathFinder: findDetected, CustomLibraryVdfPath: external},
+			wantSteamPath:  detected,
+			wantLibraryVdf: external,
+		},
+		{
+			name:           "custom steam path and custom library vdf",
+			config:         SteamReaderConfig{SteamPathFinder: findNothing, CustomSteamPath: bare, CustomLibraryVdfPath: external},
+			wantSteamPath:  bare,
+			wantLibraryVdf: external,
+		},
+		{
+			name:    "missing custom library vdf is not replaced by finder",
+			config:  SteamReaderConfig{SteamPathFinder: findDetected, CustomLibraryVdfPath: filepath.Join(root, "missing.vdf")},
+			wantErr: true,
+		},
+		{
+			name:    "custom library vdf that is not a libraryfolders.vdf is not replaced by finder",
+			config:  SteamReaderConfig{SteamPathFinder: findDetected, CustomLibraryVdfPath: notLibraryVdf},
+			wantErr: true,
+		},
+		{
+			name:    "bad custom library vdf with custom steam path",
+			config:  SteamReaderConfig{SteamPathFinder: findNothing, CustomSteamPath: bare, CustomLibraryVdfPath: notLibraryVdf},
+			wantErr: true,
+		},
+		{
+			name:           "steam path derived from custom library vdf",
+			config:         SteamReaderConfig{SteamPathFinder: findNothing, CustomLibraryVdfPath: filepath.Join(custom, "steamapps", "libraryfolders.vdf")},
+			wantSteamPath:  custom,
+			wantLibraryVdf: filepath.Join(custom, "steamapps", "libraryfolders.vdf"),
+		},
+		{
+			name:    "custom library vdf outside a steam tree cannot replace the finder",
+			config:  SteamReaderConfig{SteamPathFinder: findNothing, CustomLibraryVdfPath: external},
+			wantErr: true,
+		},
+		{
+			name: "custom library finder",
+			config: SteamReaderConfig{
+				SteamPathFinder:      findDetected,
+				LibraryVdfPathFinder: func(string) (string, error) { return external, nil },
+			},
+			wantSteamPath:  detected,
+			wantLibraryVdf: external,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.config.HomeDir = root
+			reader, err := NewSteamReader(test.config)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("NewSteamReader succeeded with Steam path %q, want error", reader.GetSteamPath())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewSteamReader: %v", err)
+			}
+
+			if got := reader.GetSteamPath(); got != test.wantSteamPath {
+				t.Errorf("GetSteamPath() = %q, want %q", got, test.wantSteamPath)
+			}
+			if got := reader.GetLibraryVdfPath(); got != test.wantLibraryVdf {
+				t.Errorf("GetLibraryVdfPath() = %q, want %q", got, test.wantLibraryVdf)
+			}
+			if reader.GetLibraryVdfMap() == nil {
+				t.Error("GetLibraryVdfMap() = nil")
+			}
+		})
+	}
+}
+
+func TestFindAppIDPath(t *testing.T) {
+	steamPath := makeSteamFixture(t, t.TempDir())
+	manifest := filepath.Join(steamPath, "steamapps", "appmanifest_100.acf")
+	if err := os.WriteFile(manifest, []byte("\"AppState\"\n{\n\t\"appid\"\t\t\"100\"\n}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	reader, err := NewSteamReader(SteamReaderConfig{CustomSteamPath: steamPath, HomeDir: steamPath})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The fixture's "contentstatsid" entry is not a library and is skipped.
+	if got, err := reader.FindAppIDPath("100"); err != nil || got != steamPath {
+		t.Errorf("FindAppIDPath(100) = %q, %v; want %q", got, err, steamPath)
+	}
+	if _, err := reader.FindAppIDPath("200"); !errors.Is(err, ErrAppNotFound) {
+		t.Errorf("FindAppIDPath(200) error = %v, want ErrAppNotFound", err)
+	}
+
+	vdf := filepath.Join(steamPath, "steamapps", "libraryfolders.vdf")
+	if err := os.WriteFile(vdf, []byte("\"libraryfolders\"\n{\n\t\"0\"\n\t{\n\t\t\"label\"\t\t\"\"\n\t}\n}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	reader, err = NewSteamReader(SteamReaderConfig{CustomSteamPath: steamPath, HomeDir: steamPath})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := reader.FindAppIDPath("100"); err == nil {
+		t.Error("FindAppIDPath succeeded with a library that has no path")
+	}
+}
+
+// The code in this file was made by an LLM, use in production is highly discouraged as unexpected results may occur. The code in this file is not vetted for stability or edge cases.
@@ -4,53 +4,296 @@
 package steamutils
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
 	"strings"
 
+	"golang.org/x/sys/windows"
 	"golang.org/x/sys/windows/registry"
 )
 
 // GetSteamPath finds Steam's installation path from Windows Registry
 func GetSteamPath() (string, error) {
-	root := registry.CURRENT_USER
-	keyPath := `Software\Valve\Steam`
+	return steamPathForHome("")
+}
 
-	SteamPath, err := readStringValueWithDefault(root, keyPath, "SteamPath", "")
+// steamPathForHome finds Steam's installation path from Windows Registry.
+// The registry is per user already, so homeDir is not used.
+func steamPathForHome(homeDir string) (string, error) {
+	reg, err := OpenRegistry()
 	if err != nil {
 		return "", err
 	}
 
-	SteamPath = strings.ReplaceAll(SteamPath, "/", "\\")
+	SteamPath := strings.ReplaceAll(reg.SteamPath(), "/", "\\")
 	return SteamPath, nil
 }
 
 // GetAutoLoggedInSteamUsername returns Steam username that has autologin enabled (Windows only)
 func GetAutoLoggedInSteamUsername() (string, error) {
-	root := registry.CURRENT_USER
-	keyPath := `Software\Valve\Steam`
+	return autoLoginUserForHome("")
+}
+
+// autoLoginUserForHome returns the auto-login Steam username from the registry.
+// homeDir is not used on Windows.
+func autoLoginUserForHome(homeDir string) (string, error) {
+	reg, err := OpenRegistry()
+	if err != nil {
+		return "", err
+	}
+
+	return reg.AutoLoginUser(), nil
+}
+
+// steamPathCandidates returns the paths DiscoverSteamInstallations checks.
+// homeDir is not used on Windows.
+func steamPathCandidates(homeDir string) ([]installationCandidate, error) {
+	reg, err := OpenRegistry()
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := []installationCandidate{
+		{InstallationNative, strings.ReplaceAll(reg.SteamPath(), "/", "\\")},
+	}
+
+	// The installer records the machine-wide location under HKLM.
+	for _, keyPath := range []string{`HKLM\SOFTWARE\WOW6432Node\Valve\Steam`, `HKLM\SOFTWARE\Valve\Steam`} {
+		if installPath, ok := reg.Value(keyPath, "InstallPath"); ok {
+			candidates = append(candidates, installationCandidate{InstallationSystem, installPath})
+		}
+	}
+
+	candidates = append(candidates, installationCandidate{InstallationEnv, os.Getenv("STEAM_PATH")})
+	return candidates, nil
+}
+
+// windowsRegistry is a Registry backed by the Windows registry.
+type windowsRegistry struct{}
+
+// OpenRegistry returns the Windows registry as a Registry.
+func OpenRegistry() (Registry, error) {
+	return windowsRegistry{}, nil
+}
+
+// openRegistryForHome returns the Windows registry; homeDir is not used.
+func openRegistryForHome(homeDir string) (Registry, error) {
+	return OpenRegistry()
+}
+
+// openKey opens a Windows-style key path such as HKCU\Software\Valve\Steam.
+func (windowsRegistry) openKey(keyPath string, access uint32) (registry.Key, error) {
+	root, subPath, _ := strings.Cut(strings.Trim(keyPath, `\`), `\`)
+
+	var hive registry.Key
+	switch strings.ToUpper(root) {
+	case "HKCU", "HKEY_CURRENT_USER":
+		hive = registry.CURRENT_USER
+	case "HKLM", "HKEY_LOCAL_MACHINE":
+		hive = registry.LOCAL_MACHINE
+	default:
+		return 0, fmt.Errorf("unsupported registry hive %s", root)
+	}
 
-	UserName, err := readStringValueWithDefault(root, keyPath, "AutoLoginUser", "")
-	return UserName, err
+	return registry.OpenKey(hive, subPath, access)
 }
 
-// readStringValueWithDefault reads a string value from the Windows Registry with a default value
-func readStringValueWithDefault(root registry.Key, keyPath, valueName, defaultValue string) (string, error) {
-	k, err := registry.OpenKey(root, keyPath, registry.QUERY_VALUE)
+// Value implements Registry.
+func (reg windowsRegistry) Value(keyPath, name string) (string, bool) {
+	k, err := reg.openKey(keyPath, registry.QUERY_VALUE)
 	if err != nil {
-		return defaultValue, nil // Return the default value if the key or value doesn't exist
+		return "", false
 	}
 	defer k.Close()
 
-	value, _, err := k.GetStringValue(valueName)
+	if value, _, err := k.GetStringValue(name); err == nil {
+		return value, true
+	}
+	if value, _, err := k.GetIntegerValue(name); err == nil {
+		return strconv.FormatUint(value, 10), true
+	}
+	return "", false
+}
+
+// SubKeys implements Registry.
+func (reg windowsRegistry) SubKeys(keyPath string) []string {
+	k, err := reg.openKey(keyPath, registry.ENUMERATE_SUB_KEYS)
+	if err != nil {
+		return nil
+	}
+	defer k.Close()
+
+	names, _ := k.ReadSubKeyNames(-1)
+	return names
+}
+
+// SteamPath implements Registry.
+func (reg windowsRegistry) SteamPath() string {
+	value, _ := reg.Value(steamRegistryKey, "SteamPath")
+	return value
+}
+
+// AutoLoginUser implements Registry.
+func (reg windowsRegistry) AutoLoginUser() string {
+	value, _ := reg.Value(steamRegistryKey, "AutoLoginUser")
+	return value
+}
+
+// Language implements Registry.
+func (reg windowsRegistry) Language() string {
+	value, _ := reg.Value(steamRegistryKey, "Language")
+	return value
+}
+
+// AppIDs implements Registry.
+func (reg windowsRegistry) AppIDs() []string {
+	return reg.SubKeys(steamRegistryKey + `\Apps`)
+}
+
+// AppValue implements Registry.
+func (reg windowsRegistry) AppValue(appID, name string) (string, bool) {
+	return reg.Value(steamRegistryKey+`\Apps\`+appID, name)
+}
+
+// readSteamStatus reads the Steam client state from HKCU\Software\Valve\Steam.
+// homeDir is not used on Windows.
+func readSteamStatus(homeDir string) (*SteamStatus, error) {
+	status := &SteamStatus{}
+
+	reg, err := OpenRegistry()
+	if err != nil {
+		return nil, err
+	}
+	statusFromRegistry(reg, status)
+
+	status.Running = status.PID > 0 && processAlive(uint32(status.PID))
+	if !status.Running {
+		status.clearRunning()
+	}
+
+	return status, nil
+}
+
+// processAlive reports whether the process with the given pid is still running.
+func processAlive(pid uint32) bool {
+	handle, err := windows.OpenProcess(windows.PROCESS_QUERY_LIMITED_INFORMATION, false, pid)
+	if err != nil {
+		return false
+	}
+	defer windows.CloseHandle(handle)
+
+	var exitCode uint32
+	if err := windows.GetExitCodeProcess(handle, &exitCode); err != nil {
+		return false
+	}
+
+	// STILL_ACTIVE
+	return exitCode == 259
+}
+
+// defaultUserSource returns the accounts ScanSystem inspects by default.
+// Steam keeps its settings in each user's registry hive, so scanning other
+// users requires a UserSource and is limited to their files.
+func defaultUserSource() (UserSource, error) {
+	return nil, fmt.Errorf("ScanSystem needs an explicit UserSource on Windows")
+}
+
+// detectPlatform reports the operating system; SteamOS and Steam Deck are
+// Linux only.
+func detectPlatform() Platform {
+	return Platform{OS: runtime.GOOS}
+}
+
+// classifyLibraryDevice is not implemented on this platform; libraries are
+// reported as unknown and mounted.
+func classifyLibraryDevice(path string) (DeviceClass, bool) {
+	return DeviceUnknown, true
+}
+
+// diskUsage returns the total and free bytes of the volume holding path.
+func diskUsage(path string) (total, free uint64, err error) {
+	pathPtr, err := windows.UTF16PtrFromString(path)
 	if err != nil {
-		return defaultValue, nil // Return the default value if the value doesn't exist
+		return 0, 0, err
 	}
 
-	return value, nil
+	var freeToCaller, totalBytes, totalFree uint64
+	if err := windows.GetDiskFreeSpaceEx(pathPtr, &freeToCaller, &totalBytes, &totalFree); err != nil {
+		return 0, 0, err
+	}
+	return totalBytes, freeToCaller, nil
 }
 
-// pathSeparator returns the OS-specific path separator
-func pathSeparator() string {
-	return "\\"
+// platformCanonicalPath returns the path Windows reports for the opened file,
+// which has the on-disk casing and long (non 8.3) names. The \\?\ prefix is
+// dropped because the os package adds it back for long paths when needed.
+func platformCanonicalPath(path string) string {
+	pathPtr, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return path
+	}
+
+	// FILE_FLAG_BACKUP_SEMANTICS is required to open directories.
+	handle, err := windows.CreateFile(pathPtr, 0,
+		windows.FILE_SHARE_READ|windows.FILE_SHARE_WRITE|windows.FILE_SHARE_DELETE,
+		nil, windows.OPEN_EXISTING, windows.FILE_FLAG_BACKUP_SEMANTICS, 0)
+	if err != nil {
+		return path
+	}
+	defer windows.CloseHandle(handle)
+
+	// Flags 0 are FILE_NAME_NORMALIZED and VOLUME_NAME_DOS.
+	buf := make([]uint16, windows.MAX_LONG_PATH)
+	n, err := windows.GetFinalPathNameByHandle(handle, &buf[0], uint32(len(buf)), 0)
+	if err != nil || n == 0 || int(n) > len(buf) {
+		return path
+	}
+
+	final := windows.UTF16ToString(buf[:n])
+	if rest, found := strings.CutPrefix(final, `\\?\UNC\`); found {
+		return `\\` + rest
+	}
+	return strings.TrimPrefix(final, `\\?\`)
+}
+
+// copyFileOwner does nothing on Windows, where a new file inherits its access
+// control list from the directory.
+func copyFileOwner(path string, like os.FileInfo) error {
+	return nil
+}
+
+// syncDir does nothing on Windows, where directories cannot be opened for
+// syncing.
+func syncDir(dir string) {}
+
+// nativeSaveRoot locates the Windows Auto-Cloud roots in the profile at
+// homeDir, so the saves of other users can be found too.
+func nativeSaveRoot(homeDir, root string) (string, bool) {
+	if root == "WinProgramData" {
+		if programData := os.Getenv("ProgramData"); programData != "" {
+			return programData, true
+		}
+		return `C:\ProgramData`, true
+	}
+	if homeDir == "" {
+		return "", false
+	}
+	switch root {
+	case "WinMyDocuments":
+		return filepath.Join(homeDir, "Documents"), true
+	case "WinAppDataLocal":
+		return filepath.Join(homeDir, "AppData", "Local"), true
+	case "WinAppDataRoaming":
+		return filepath.Join(homeDir, "AppData", "Roaming"), true
+	case "WinAppDataLocalLow":
+		return filepath.Join(homeDir, "AppData", "LocalLow"), true
+	case "WinSavedGames":
+		return filepath.Join(homeDir, "Saved Games"), true
+	}
+	return "", false
 }
 
 // The code in this file was made by an LLM, use in production is highly discouraged as unexpected results may occur. The code in this file is not vetted for stability or edge cases.
@@ -0,0 +1,82 @@
+package steamutils
+
+// AppStatus holds the per-app state flags Steam keeps in its registry.
+type AppStatus struct {
+	// AppID is the application identifier.
+	AppID string
+
+	// Name is the application name, when Steam recorded one.
+	Name string
+
+	// Installed reports whether Steam considers the app installed.
+	Installed bool
+
+	// Running reports whether the app is currently running. It is always
+	// false while the Steam client is not running.
+	Running bool
+
+	// Updating reports whether the app is being updated or downloaded.
+	Updating bool
+}
+
+// SteamStatus describes the state of the Steam client.
+//
+// WARNING: This type was generated by an LLM. Thoroughly test before production use.
+type SteamStatus struct {
+	// Running reports whether a Steam client process is alive.
+	Running bool
+
+	// PID is the process ID of the Steam client, or 0 if it is not running.
+	PID int
+
+	// RunningAppID is the app currently being played, or "" if none.
+	RunningAppID string
+
+	// Apps lists the per-app flags found in the registry, ordered by AppID.
+	Apps []AppStatus
+}
+
+// UpdatingApps returns the IDs of apps Steam reports as updating.
+func (status *SteamStatus) UpdatingApps() []string {
+	var appIDs []string
+	for _, app := range status.Apps {
+		if app.Updating {
+			appIDs = append(appIDs, app.AppID)
+		}
+	}
+	return appIDs
+}
+
+// clearRunning drops the PID and every running flag, which Steam leaves set in
+// its registry after a crash, for a client that is not running.
+func (status *SteamStatus) clearRunning() {
+	status.PID = 0
+	status.RunningAppID = ""
+	for i := range status.Apps {
+		status.Apps[i].Running = false
+	}
+}
+
+// SteamStatus reports whether Steam is running, its PID, the app currently
+// being played and per-app running/updating flags.
+//
+// On Windows this reads HKCU\Software\Valve\Steam. On Linux and macOS it reads
+// the pid file and registry.vdf that Steam maintains in the user's home.
+//
+// Any write operation on Steam's files is unsafe while Running is true.
+//
+// WARNING: This method was generated by an LLM. Thoroughly test before production use.
+func (steamreader *SteamReader) SteamStatus() (*SteamStatus, error) {
+	return readSteamStatus(steamreader.homeDir)
+}
+
+// steamRunning reports whether the Steam client is running.
+func (steamreader *SteamReader) steamRunning() (bool, error) {
+	status, err := readSteamStatus(steamreader.homeDir)
+	if err != nil {
+		return false, err
+	}
+	return status.Running, nil
+}
+
+// The code in this file was made by an LLM, use in production is highly discouraged as unexpected results may occur. The code in this file is not vetted for stability or edge cases.
@@ -0,0 +1,206 @@
+package steamutils
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SystemUser is a local OS account whose home directory may hold a Steam
+// installation.
+type SystemUser struct {
+	// Name is the login name.
+	Name string
+
+	// UID is the numeric user ID as a string, or "" if unknown.
+	UID string
+
+	// HomeDir is the user's home directory.
+	HomeDir string
+}
+
+// UserSource enumerates the local OS accounts a system scan should inspect.
+type UserSource interface {
+	Users() ([]SystemUser, error)
+}
+
+// PasswdUserSource reads accounts from a file in /etc/passwd format.
+type PasswdUserSource struct {
+	// Path is the passwd file to read. Defaults to /etc/passwd.
+	Path string
+}
+
+// Users implements UserSource.
+func (source PasswdUserSource) Users() ([]SystemUser, error) {
+	path := source.Path
+	if path == "" {
+		path = "/etc/passwd"
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var users []SystemUser
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// name:password:uid:gid:gecos:home:shell
+		fields := strings.Split(line, ":")
+		if len(fields) < 7 {
+			continue
+		}
+
+		users = append(users, SystemUser{
+			Name:    fields[0],
+			UID:     fields[2],
+			HomeDir: fields[5],
+		})
+	}
+
+	return users, scanner.Err()
+}
+
+// DirectoryUserSource treats every subdirectory of Root as a home directory,
+// such as /home on Linux or /Users on macOS.
+type DirectoryUserSource struct {
+	Root string
+}
+
+// Users implements UserSource.
+func (source DirectoryUserSource) Users() ([]SystemUser, error) {
+	entries, err := os.ReadDir(source.Root)
+	if err != nil {
+		return nil, err
+	}
+
+	var users []SystemUser
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		users = append(users, SystemUser{
+			Name:    entry.Name(),
+			HomeDir: filepath.Join(source.Root, entry.Name()),
+		})
+	}
+	return users, nil
+}
+
+// UserScanResult is the Steam data found for one OS user.
+type UserScanResult struct {
+	// User is the OS account that was scanned.
+	User SystemUser
+
+	// Reader is a SteamReader for the user's Steam installation, or nil if
+	// Err is set.
+	Reader *SteamReader
+
+	// Apps lists the user's installed apps.
+	Apps []InstalledApp
+
+	// Err is set when the user has Steam data that could not be read, for
+	// example because their home directory is not readable.
+	Err error
+}
+
+// SystemScanOptions configures ScanSystem.
+type SystemScanOptions struct {
+	// Users enumerates the accounts to scan. If nil, /etc/passwd is used on
+	// Linux and /Users on macOS.
+	Users UserSource
+}
+
+// ScanSystem inventories the Steam libraries and games of every local OS user.
+//
+// Users whose home directory does not exist or contains no Steam installation
+// of their own are left out; system-wide installations and STEAM_PATH are not
+// attributed to any user. Users whose data cannot be read, for example because of
+// permission-denied directories, are included with Err set, so a scan run
+// without root privileges still returns everything it can access.
+//
+// WARNING: This function was generated by an LLM. Thoroughly test before production use.
+func ScanSystem(opts SystemScanOptions) ([]UserScanResult, error) {
+	source := opts.Users
+	if source == nil {
+		var err error
+		source, err = defaultUserSource()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	users, err := source.Users()
+	if err != nil {
+		return nil, fmt.Errorf("failed to enumerate users: %w", err)
+	}
+
+	var results []UserScanResult
+	seenHomes := make(map[string]bool)
+	for _, u := range users {
+		if u.HomeDir == "" || u.HomeDir == "/" || seenHomes[u.HomeDir] {
+			continue
+		}
+		seenHomes[u.HomeDir] = true
+
+		if _, err := os.ReadDir(u.HomeDir); err != nil {
+			if errors.Is(err, fs.ErrPermission) {
+				results = append(results, UserScanResult{User: u, Err: err})
+			}
+			continue
+		}
+
+		installations, err := discoverSteamInstallations(u.HomeDir)
+		if err != nil {
+			results = append(results, UserScanResult{User: u, Err: err})
+			continue
+		}
+
+		steamPath := userSteamPath(installations)
+		if steamPath == "" {
+			continue
+		}
+
+		reader, err := NewSteamReader(SteamReaderConfig{
+			HomeDir: u.HomeDir,
+			SteamPathFinder: func() (string, error) {
+				return steamPath, nil
+			},
+		})
+		if err != nil {
+			results = append(results, UserScanResult{User: u, Err: err})
+			continue
+		}
+
+		result := UserScanResult{User: u, Reader: &reader}
+		result.Apps, result.Err = reader.GetAllInstalledApps()
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// userSteamPath returns the first installation that belongs to the scanned
+// user. STEAM_PATH belongs to the scanning process, and a system-wide
+// installation such as /usr/share/steam is shared by every account, so neither
+// is attributed to the user.
+func userSteamPath(installations []SteamInstallation) string {
+	for _, installation := range installations {
+		if installation.Kind != InstallationEnv && installation.Kind != InstallationSystem {
+			return installation.Path
+		}
+	}
+	return ""
+}
+
+// The code in this file was made by an LLM, use in production is highly discouraged as unexpected results may occur. The code in this file is not vetted for stability or edge cases.
@@ -0,0 +1,70 @@
+package steamutils
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestScanSystemDirectoryUserSource(t *testing.T) {
+	var steamDir string
+	switch runtime.GOOS {
+	case "linux":
+		steamDir = filepath.Join(".local", "share", "Steam")
+	case "darwin":
+		steamDir = filepath.Join("Library", "Application Support", "Steam")
+	default:
+		t.Skip("home directory scanning is only implemented on Linux and macOS")
+	}
+
+	root := t.TempDir()
+	homes := filepath.Join(root, "home")
+	alice := filepath.Join(homes, "alice")
+	makeSteamFixture(t, filepath.Join(alice, steamDir))
+	for _, dir := range []string{filepath.Join(homes, "daemon"), filepath.Join(homes, ".hidden")} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// STEAM_PATH belongs to the scanning process and must not be reported
+	// for users without a Steam installation of their own.
+	t.Setenv("STEAM_PATH", makeSteamFixture(t, filepath.Join(root, "env-steam")))
+
+	results, err := ScanSystem(SystemScanOptions{Users: DirectoryUserSource{Root: homes}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("ScanSystem returned %d results, want 1: %+v", len(results), results)
+	}
+
+	result := results[0]
+	if result.User.Name != "alice" || result.Err != nil {
+		t.Fatalf("result = user %q, err %v; want alice without error", result.User.Name, result.Err)
+	}
+	want, err := filepath.EvalSymlinks(filepath.Join(alice, steamDir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := result.Reader.GetSteamPath(); got != want {
+		t.Errorf("GetSteamPath() = %q, want %q", got, want)
+	}
+}
+
+func TestUserSteamPath(t *testing.T) {
+	installations := []SteamInstallation{
+		{Kind: InstallationSystem, Path: "/usr/share/steam"},
+		{Kind: InstallationEnv, Path: "/opt/steam"},
+		{Kind: InstallationFlatpak, Path: "/home/alice/.var/app/com.valvesoftware.Steam/.local/share/Steam"},
+	}
+	if got, want := userSteamPath(installations), installations[2].Path; got != want {
+		t.Errorf("userSteamPath = %q, want %q", got, want)
+	}
+	if got := userSteamPath(installations[:2]); got != "" {
+		t.Errorf("userSteamPath with only shared installations = %q, want \"\"", got)
+	}
+}
+
+// The code in this file was made by an LLM, use in production is highly discouraged as unexpected results may occur. The code in this file is not vetted for stability or edge cases.
@@ -0,0 +1,214 @@
+package steamutils
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/iancoleman/orderedmap"
+)
+
+// EncodeAppManifest renders app as the contents of an appmanifest_<appid>.acf
+// file.
+//
+// Encoding starts from app.Raw, so keys the InstalledApp fields do not cover,
+// such as UserConfig or MountedConfig, keep their values and order, and
+// changes made directly to Raw are written too. A typed field is only written
+// when its value differs from what Raw holds, so an unchanged app encodes to
+// its original keys without gaining ones Steam never wrote; a changed field
+// overwrites its key. Without Raw, or if Raw cannot be parsed, every typed
+// field is written. FullPath, LibraryPath, Unavailable and ManifestError are
+// derived when reading and are not stored. app.Raw itself is not modified.
+//
+// WARNING: This function was generated by an LLM. Thoroughly test before production use.
+func EncodeAppManifest(app *InstalledApp) ([]byte, error) {
+	if app.AppID == "" {
+		return nil, fmt.Errorf("app has no AppID")
+	}
+
+	appState := orderedmap.New()
+	var base InstalledApp
+	writeAll := app.Raw == nil
+	if !writeAll {
+		appState = cloneVDF(app.Raw)
+		var err error
+		base, err = appFromAppState(app.Raw)
+		writeAll = err != nil
+	}
+
+	set := func(key string, changed bool, value string) {
+		if writeAll || changed {
+			setVDFKey(appState, key, value)
+		}
+	}
+	set("appid", app.AppID != base.AppID, app.AppID)
+	set("name", app.Name != base.Name, app.Name)
+	set("installdir", app.InstallDir != base.InstallDir, app.InstallDir)
+	set("buildid", app.BuildID != base.BuildID, app.BuildID)
+	set("StateFlags", app.StateFlags != base.StateFlags, strconv.FormatInt(app.StateFlags, 10))
+	set("SizeOnDisk", app.SizeOnDisk != base.SizeOnDisk, strconv.FormatInt(app.SizeOnDisk, 10))
+	set("LastUpdated", !app.LastUpdated.Equal(base.LastUpdated), manifestTimeString(app.LastUpdated))
+	set("LastPlayed", !app.LastPlayed.Equal(base.LastPlayed), manifestTimeString(app.LastPlayed))
+	set("BytesToDownload", app.BytesToDownload != base.BytesToDownload, strconv.FormatInt(app.BytesToDownload, 10))
+	set("BytesDownloaded", app.BytesDownloaded != base.BytesDownloaded, strconv.FormatInt(app.BytesDownloaded, 10))
+	set("AutoUpdateBehavior", app.AutoUpdateBehavior != base.AutoUpdateBehavior, strconv.Itoa(int(app.AutoUpdateBehavior)))
+	set("AllowOtherDownloadsWhileRunning", app.AllowOtherDownloadsWhileRunning != base.AllowOtherDownloadsWhileRunning,
+		strconv.Itoa(int(app.AllowOtherDownloadsWhileRunning)))
+
+	if writeAll || !slices.Equal(app.InstalledDepots, base.InstalledDepots) {
+		if err := encodeInstalledDepots(appState, app.InstalledDepots, base.InstalledDepots); err != nil {
+			return nil, err
+		}
+	}
+
+	manifest := orderedmap.New()
+	manifest.Set("AppState", appState)
+	return Marshal(manifest)
+}
+
+// encodeInstalledDepots rebuilds the InstalledDepots block of appState from
+// depots. Entries of depots that are unchanged from base are kept as they
+// are, and changed ones keep any keys Steam stores beyond those
+// InstalledDepot covers.
+func encodeInstalledDepots(appState *orderedmap.OrderedMap, depots, base []InstalledDepot) error {
+	baseDepots := map[uint64]InstalledDepot{}
+	for _, depot := range base {
+		baseDepots[depot.DepotID] = depot
+	}
+
+	oldDepots, _ := vdfLookup(appState, "InstalledDepots")
+	oldDepotsMap, _ := oldDepots.(*orderedmap.OrderedMap)
+	block := orderedmap.New()
+	for _, depot := range depots {
+		depotKey := strconv.FormatUint(depot.DepotID, 10)
+		if _, exists := block.Get(depotKey); exists {
+			return fmt.Errorf("depot %s is listed twice", depotKey)
+		}
+
+		entry := orderedmap.New()
+		if old, ok := vdfLookup(oldDepotsMap, depotKey); ok {
+			if oldMap, ok := old.(*orderedmap.OrderedMap); ok {
+				entry = oldMap
+			}
+		}
+		if baseDepot, ok := baseDepots[depot.DepotID]; !ok || baseDepot != depot || len(entry.Keys()) == 0 {
+			setVDFKey(entry, "manifest", strconv.FormatUint(depot.Manifest, 10))
+			setVDFKey(entry, "size", strconv.FormatInt(depot.Size, 10))
+			if depot.DLCAppID != "" {
+				setVDFKey(entry, "dlcappid", depot.DLCAppID)
+			} else {
+				deleteVDFKey(entry, "dlcappid")
+			}
+		}
+		block.Set(depotKey, entry)
+	}
+	if oldDepotsMap != nil || len(depots) > 0 {
+		setVDFKey(appState, "InstalledDepots", block)
+	}
+	return nil
+}
+
+// UpdateManifest applies mutate to the installed app appID and writes the
+// result back to its appmanifest_<appid>.acf.
+//
+// mutate receives the app as GetInstalledAppByID returns it and may change its
+// fields or its Raw map, for example to clear a stuck update or set
+// AutoUpdateBehavior; see EncodeAppManifest for how the two are combined.
+// Changing AppID is an error. The manifest is replaced as WriteVDFFile does,
+// keeping previous versions for RestoreVDFFile. Refuses to run while
+// Steam is running, since Steam rewrites manifests from memory.
+//
+// Returns the app as read back from the new manifest.
+//
+// WARNING: This method was generated by an LLM. Thoroughly test before production use.
+func (steamreader *SteamReader) UpdateManifest(appID string, mutate func(app *InstalledApp)) (*InstalledApp, error) {
+	if running, err := steamreader.steamRunning(); err != nil {
+		return nil, err
+	} else if running {
+		return nil, ErrSteamRunning
+	}
+
+	app, err := steamreader.GetInstalledAppByID(appID)
+	if err != nil {
+		return nil, err
+	}
+
+	mutate(app)
+	if app.AppID != appID {
+		return nil, fmt.Errorf("UpdateManifest cannot change the AppID of app %s", appID)
+	}
+
+	data, err := EncodeAppManifest(app)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode manifest for app %s: %w", appID, err)
+	}
+
+	// Refuse to write anything that would not read back cleanly.
+	check, err := Unmarshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("encoded manifest for app %s does not parse: %w", appID, err)
+	}
+	checkState, _ := vdfLookup(check, "AppState")
+	checkMap, _ := checkState.(*orderedmap.OrderedMap)
+	if checkMap == nil {
+		return nil, fmt.Errorf("encoded manifest for app %s has no AppState", appID)
+	}
+	if _, err := appFromAppState(checkMap); err != nil {
+		return nil, fmt.Errorf("encoded manifest for app %s is invalid: %w", appID, err)
+	}
+
+	manifestPath := filepath.Join(app.LibraryPath, "steamapps", fmt.Sprintf("appmanifest_%s.acf", appID))
+	if err := writeFileAtomic(manifestPath, data); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", manifestPath, err)
+	}
+
+	return steamreader.GetInstalledAppByID(appID)
+}
+
+// cloneVDF returns a deep copy of a parsed VDF map.
+func cloneVDF(m *orderedmap.OrderedMap) *orderedmap.OrderedMap {
+	clone := orderedmap.New()
+	for _, key := range m.Keys() {
+		value, _ := m.Get(key)
+		if inner, ok := value.(*orderedmap.OrderedMap); ok {
+			value = cloneVDF(inner)
+		}
+		clone.Set(key, value)
+	}
+	return clone
+}
+
+// setVDFKey sets key in m, reusing the spelling of an existing key that
+// matches case-insensitively so its position and case are kept.
+func setVDFKey(m *orderedmap.OrderedMap, key string, value interface{}) {
+	for _, k := range m.Keys() {
+		if strings.EqualFold(k, key) {
+			m.Set(k, value)
+			return
+		}
+	}
+	m.Set(key, value)
+}
+
+// deleteVDFKey removes every key in m matching key case-insensitively.
+func deleteVDFKey(m *orderedmap.OrderedMap, key string) {
+	for _, k := range m.Keys() {
+		if strings.EqualFold(k, key) {
+			m.Delete(k)
+		}
+	}
+}
+
+// manifestTimeString formats t as the Unix timestamp stored in manifests, with
+// the zero time as 0.
+func manifestTimeString(t time.Time) string {
+	if t.IsZero() {
+		return "0"
+	}
+	return strconv.FormatInt(t.Unix(), 10)
+}
+
+// The code in this file was made by an LLM, use in production is highly discouraged as unexpected results may occur. The code in this file is not vetted for stability or edge cases.
@@ -0,0 +1,175 @@
+package steamutils
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/iancoleman/orderedmap"
+)
+
+// appManifestFixture is a manifest in the layout Steam writes, with keys
+// InstalledApp has no field for and no AutoUpdateBehavior or
+// BytesToDownload keys.
+const appManifestFixture = "\"AppState\"\n{\n" +
+	"\t\"appid\"\t\t\"570\"\n" +
+	"\t\"universe\"\t\t\"1\"\n" +
+	"\t\"LauncherPath\"\t\t\"C:\\\\Program Files (x86)\\\\Steam\\\\steam.exe\"\n" +
+	"\t\"name\"\t\t\"Dota 2\"\n" +
+	"\t\"StateFlags\"\t\t\"4\"\n" +
+	"\t\"installdir\"\t\t\"dota 2 beta\"\n" +
+	"\t\"LastUpdated\"\t\t\"1700000000\"\n" +
+	"\t\"SizeOnDisk\"\t\t\"40000\"\n" +
+	"\t\"buildid\"\t\t\"123\"\n" +
+	"\t\"InstalledDepots\"\n\t{\n" +
+	"\t\t\"571\"\n\t\t{\n\t\t\t\"manifest\"\t\t\"111\"\n\t\t\t\"size\"\t\t\"30000\"\n\t\t}\n" +
+	"\t\t\"1241930\"\n\t\t{\n\t\t\t\"manifest\"\t\t\"222\"\n\t\t\t\"size\"\t\t\"10000\"\n\t\t\t\"dlcappid\"\t\t\"1241930\"\n\t\t\t\"language\"\t\t\"german\"\n\t\t}\n" +
+	"\t}\n" +
+	"\t\"UserConfig\"\n\t{\n\t\t\"language\"\t\t\"english\"\n\t}\n" +
+	"\t\"MountedConfig\"\n\t{\n\t\t\"language\"\t\t\"english\"\n\t}\n" +
+	"}\n"
+
+// appFromFixture parses manifest as readAppManifest does.
+func appFromFixture(t *testing.T, manifest string) *InstalledApp {
+	t.Helper()
+	parsed, err := Unmarshal([]byte(manifest))
+	if err != nil {
+		t.Fatal(err)
+	}
+	appState, _ := vdfLookup(parsed, "AppState")
+	app, err := appFromAppState(appState.(*orderedmap.OrderedMap))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &app
+}
+
+func TestEncodeAppManifestUnchanged(t *testing.T) {
+	app := appFromFixture(t, appManifestFixture)
+	data, err := EncodeAppManifest(app)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != appManifestFixture {
+		t.Errorf("EncodeAppManifest of an unchanged app =\n%s\nwant\n%s", data, appManifestFixture)
+	}
+}
+
+func TestEncodeAppManifestChanges(t *testing.T) {
+	app := appFromFixture(t, appManifestFixture)
+	app.AutoUpdateBehavior = AutoUpdateBehavior(1)
+	app.StateFlags = 6
+	app.InstalledDepots[0].Manifest = 333
+	app.InstalledDepots[1].Size = 12000
+
+	data, err := EncodeAppManifest(app)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(data)
+	for _, want := range []string{
+		"\t\"StateFlags\"\t\t\"6\"\n",
+		"\t\"AutoUpdateBehavior\"\t\t\"1\"\n",
+		"\t\t\t\"manifest\"\t\t\"333\"\n",
+		"\t\t\t\"size\"\t\t\"12000\"\n\t\t\t\"dlcappid\"\t\t\"1241930\"\n\t\t\t\"language\"\t\t\"german\"\n",
+		"\t\"LauncherPath\"",
+		"\t\"UserConfig\"\n",
+		"\t\"MountedConfig\"\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("encoded manifest lacks %q:\n%s", want, got)
+		}
+	}
+	for _, absent := range []string{"BytesToDownload", "AllowOtherDownloadsWhileRunning", "LastPlayed"} {
+		if strings.Contains(got, absent) {
+			t.Errorf("encoded manifest gained unchanged key %s:\n%s", absent, got)
+		}
+	}
+
+	// Raw still describes the manifest as read.
+	if vdfString(app.Raw, "StateFlags") != "4" {
+		t.Errorf("EncodeAppManifest modified Raw: StateFlags = %q", vdfString(app.Raw, "StateFlags"))
+	}
+
+	// Dropping a depot removes only its entry.
+	app = appFromFixture(t, appManifestFixture)
+	app.InstalledDepots = app.InstalledDepots[:1]
+	data, err = EncodeAppManifest(app)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(data); strings.Contains(got, "1241930") || !strings.Contains(got, "\t\t\"571\"\n") {
+		t.Errorf("encoded manifest after removing depot 1241930:\n%s", got)
+	}
+}
+
+func TestEncodeAppManifestWithoutRaw(t *testing.T) {
+	app := &InstalledApp{AppID: "10", Name: "Counter-Strike", InstalledDepots: []InstalledDepot{{DepotID: 11, Manifest: 1, Size: 2}}}
+	data, err := EncodeAppManifest(app)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := appFromFixture(t, string(data))
+	if decoded.AppID != "10" || decoded.Name != "Counter-Strike" || len(decoded.InstalledDepots) != 1 ||
+		decoded.InstalledDepots[0] != app.InstalledDepots[0] {
+		t.Errorf("round trip without Raw = %+v", decoded)
+	}
+	if !strings.Contains(string(data), "AutoUpdateBehavior") {
+		t.Errorf("encoded manifest without Raw lacks typed keys:\n%s", data)
+	}
+
+	app.InstalledDepots = append(app.InstalledDepots, app.InstalledDepots[0])
+	if _, err := EncodeAppManifest(app); err == nil {
+		t.Error("EncodeAppManifest accepted a depot listed twice")
+	}
+	if _, err := EncodeAppManifest(&InstalledApp{}); err == nil {
+		t.Error("EncodeAppManifest accepted an app without AppID")
+	}
+}
+
+func TestUpdateManifest(t *testing.T) {
+	steamPath := makeSteamFixture(t, t.TempDir())
+	steamapps := filepath.Join(steamPath, "steamapps")
+	vdf := "\"libraryfolders\"\n{\n\t\"0\"\n\t{\n\t\t\"path\"\t\t\"" + escapeVDFString(steamPath) + "\"\n" +
+		"\t\t\"apps\"\n\t\t{\n\t\t\t\"570\"\t\t\"40000\"\n\t\t}\n\t}\n}\n"
+	if err := os.WriteFile(filepath.Join(steamapps, "libraryfolders.vdf"), []byte(vdf), 0644); err != nil {
+		t.Fatal(err)
+	}
+	manifestPath := filepath.Join(steamapps, "appmanifest_570.acf")
+	if err := os.WriteFile(manifestPath, []byte(appManifestFixture), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	reader, err := NewSteamReader(SteamReaderConfig{CustomSteamPath: steamPath, HomeDir: steamPath})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := reader.UpdateManifest("570", func(app *InstalledApp) { app.AppID = "571" }); err == nil {
+		t.Error("UpdateManifest allowed changing the AppID")
+	}
+	if data, _ := os.ReadFile(manifestPath); string(data) != appManifestFixture {
+		t.Fatalf("failed update changed the manifest to:\n%s", data)
+	}
+
+	app, err := reader.UpdateManifest("570", func(app *InstalledApp) { app.AutoUpdateBehavior = AutoUpdateBehavior(2) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if app.AutoUpdateBehavior != 2 || app.Name != "Dota 2" {
+		t.Errorf("UpdateManifest returned %+v", app)
+	}
+	if backup, err := os.ReadFile(vdfBackupPath(manifestPath, 0)); err != nil || string(backup) != appManifestFixture {
+		t.Errorf("backup = %q, %v; want the original manifest", backup, err)
+	}
+
+	if err := RestoreVDFFile(manifestPath, 1); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(manifestPath); string(data) != appManifestFixture {
+		t.Errorf("restored manifest =\n%s", data)
+	}
+}
+
+// The code in this file was made by an LLM, use in production is highly discouraged as unexpected results may occur. The code in this file is not vetted for stability or edge cases.
@@ -0,0 +1,74 @@
+package steamutils
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSetDownloadSettings(t *testing.T) {
+	steamPath := makeSteamFixture(t, t.TempDir())
+	configPath := filepath.Join(steamPath, "config", "config.vdf")
+	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	original := "\"InstallConfigStore\"\n{\n\t\"Software\"\n\t{\n\t\t\"Valve\"\n\t\t{\n\t\t\t\"Steam\"\n\t\t\t{\n" +
+		"\t\t\t\t\"AutoUpdateWindowEnabled\"\t\t\"0\"\n" +
+		"\t\t\t\t\"AutoUpdateWindowStart\"\t\t\"-1\"\n" +
+		"\t\t\t\t\"AutoUpdateWindowEnd\"\t\t\"-1\"\n" +
+		"\t\t\t}\n\t\t}\n\t}\n}\n"
+	if err := os.WriteFile(configPath, []byte(original), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	reader, err := NewSteamReader(SteamReaderConfig{CustomSteamPath: steamPath, HomeDir: steamPath})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	settings, err := reader.GetDownloadSettings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if settings.AutoUpdateWindowStart != -1 || settings.AutoUpdateWindowEnd != -1 {
+		t.Fatalf("window = %d-%d, want -1 for unset", settings.AutoUpdateWindowStart, settings.AutoUpdateWindowEnd)
+	}
+
+	// Writing back unchanged settings must not touch the file.
+	if err := reader.SetDownloadSettings(*settings); err != nil {
+		t.Fatalf("SetDownloadSettings with unchanged settings: %v", err)
+	}
+	if data, _ := os.ReadFile(configPath); string(data) != original {
+		t.Errorf("unchanged settings rewrote config.vdf:\n%s", data)
+	}
+
+	settings.DownloadThrottleKbps = 5000
+	settings.AutoUpdateWindowEnd = 6
+	if err := reader.SetDownloadSettings(*settings); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	text := string(data)
+	for _, want := range []string{`"DownloadThrottleKbps"		"5000"`, `"AutoUpdateWindowEnd"		"6"`, `"AutoUpdateWindowStart"		"-1"`} {
+		if !strings.Contains(text, want) {
+			t.Errorf("config.vdf lacks %s:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "AllowDownloadsDuringGameplay") {
+		t.Errorf("config.vdf gained an unchanged, previously absent key:\n%s", text)
+	}
+
+	for _, hour := range []int{-2, 24} {
+		bad := *settings
+		bad.AutoUpdateWindowStart = hour
+		if err := reader.SetDownloadSettings(bad); err == nil {
+			t.Errorf("SetDownloadSettings accepted hour %d", hour)
+		}
+	}
+}
+
+// The code in this file was made by an LLM, use in production is highly discouraged as unexpected results may occur. The code in this file is not vetted for stability or edge cases.
@@ -0,0 +1,96 @@
+package steamutils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/iancoleman/orderedmap"
+)
+
+// SteamUser is an account that has logged in to this Steam installation, as
+// recorded in config/loginusers.vdf.
+//
+// WARNING: This type was generated by an LLM. Thoroughly test before production use.
+type SteamUser struct {
+	// SteamID is the 64-bit Steam ID, such as "76561197960287930".
+	SteamID string
+
+	// AccountName is the login name.
+	AccountName string
+
+	// PersonaName is the display name.
+	PersonaName string
+
+	// RememberPassword reports whether Steam stores credentials for the account.
+	RememberPassword bool
+
+	// AllowAutoLogin reports whether the account may be logged in automatically.
+	AllowAutoLogin bool
+
+	// WantsOfflineMode reports whether the account starts in offline mode.
+	WantsOfflineMode bool
+
+	// MostRecent reports whether this is the most recently logged in account.
+	MostRecent bool
+
+	// Timestamp is the Unix time of the account's last login.
+	Timestamp int64
+}
+
+// GetLoginUsers returns the accounts recorded in config/loginusers.vdf, most
+// recently logged in first.
+//
+// WARNING: This method was generated by an LLM. Thoroughly test before production use.
+func (steamreader *SteamReader) GetLoginUsers() ([]SteamUser, error) {
+	data, err := os.ReadFile(filepath.Join(steamreader.steamPath, "config", "loginusers.vdf"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read loginusers.vdf: %w", err)
+	}
+
+	vdfMap, err := Unmarshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse loginusers.vdf: %w", err)
+	}
+
+	usersVal, ok := vdfLookup(vdfMap, "users")
+	if !ok {
+		return nil, fmt.Errorf("users key not found in loginusers.vdf")
+	}
+	users, ok := usersVal.(*orderedmap.OrderedMap)
+	if !ok {
+		return nil, fmt.Errorf("users is not of the expected type")
+	}
+
+	var result []SteamUser
+	for _, steamID := range users.Keys() {
+		userVal, _ := users.Get(steamID)
+		user, ok := userVal.(*orderedmap.OrderedMap)
+		if !ok {
+			continue
+		}
+
+		steamUser := SteamUser{
+			SteamID:          steamID,
+			AccountName:      vdfString(user, "AccountName"),
+			PersonaName:      vdfString(user, "PersonaName"),
+			RememberPassword: vdfString(user, "RememberPassword") == "1",
+			AllowAutoLogin:   vdfString(user, "AllowAutoLogin") == "1",
+			WantsOfflineMode: vdfString(user, "WantsOfflineMode") == "1",
+			MostRecent:       vdfString(user, "MostRecent") == "1",
+		}
+		steamUser.Timestamp, _ = strconv.ParseInt(vdfString(user, "Timestamp"), 10, 64)
+
+		result = append(result, steamUser)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Timestamp > result[j].Timestamp
+	})
+
+	return result, nil
+}
+
+// The code in this file was made by an LLM, use in production is highly discouraged as unexpected results may occur. The code in this file is not vetted for stability or edge cases.
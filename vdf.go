@@ -17,23 +17,25 @@ func skipWhitespace(s string, i int) int {
 	return i
 }
 
-// parseString expects a starting double quote and returns the string—note this simple version does not handle escapes.
+// parseString expects a starting double quote and returns the string. The
+// escapes \\ and \" are decoded; any other backslash is kept as written.
 func parseString(s string, i int) (string, int, error) {
 	if s[i] != '"' {
 		return "", i, fmt.Errorf("expected '\"' at position %d", i)
 	}
 	i++ // skip opening quote
-	start := i
+	var sb strings.Builder
 	for i < len(s) && s[i] != '"' {
+		if s[i] == '\\' && i+1 < len(s) && (s[i+1] == '\\' || s[i+1] == '"') {
+			i++
+		}
+		sb.WriteByte(s[i])
 		i++
 	}
 	if i >= len(s) {
 		return "", i, errors.New("unterminated string")
 	}
-	// Normalize doubled backslashes (some VDF sources may present escaped backslashes)
-	out := s[start:i]
-	out = strings.ReplaceAll(out, "\\\\", "\\")
-	return out, i + 1, nil // skip closing quote
+	return sb.String(), i + 1, nil // skip closing quote
 }
 
 // parseVDFOrdered recursively parses VDF text starting at position i, storing keys in order.
@@ -85,19 +87,28 @@ func parseVDFOrdered(s string, i int) (*orderedmap.OrderedMap, int, error) {
 	return ordMap, i, nil
 }
 
+// escapeVDFString escapes backslashes and double quotes so that values such as
+// Windows paths survive a round trip through parseString.
+func escapeVDFString(s string) string {
+	return vdfEscaper.Replace(s)
+}
+
+// vdfEscaper escapes the characters parseString decodes.
+var vdfEscaper = strings.NewReplacer("\\", "\\\\", "\"", "\\\"")
+
 // marshalOrderedVDF recursively serializes an ordered map to a VDF-formatted string.
 func marshalOrderedVDF(m *orderedmap.OrderedMap, indent int) (string, error) {
 	var sb strings.Builder
 	spacing := strings.Repeat("\t", indent)
 	for _, key := range m.Keys() {
 		value, _ := m.Get(key)
-		// Write the key.
-		sb.WriteString(fmt.Sprintf("%s\"%s\"\n", spacing, key))
+		// Write the key. String values follow on the same line, as Steam writes them.
+		sb.WriteString(fmt.Sprintf("%s\"%s\"", spacing, escapeVDFString(key)))
 		switch v := value.(type) {
 		case string:
-			sb.WriteString(fmt.Sprintf("%s\"%s\"\n", spacing, v))
+			sb.WriteString(fmt.Sprintf("\t\t\"%s\"\n", escapeVDFString(v)))
 		case *orderedmap.OrderedMap:
-			sb.WriteString(fmt.Sprintf("%s{\n", spacing))
+			sb.WriteString(fmt.Sprintf("\n%s{\n", spacing))
 			inner, err := marshalOrderedVDF(v, indent+1)
 			if err != nil {
 				return "", err
@@ -107,12 +118,44 @@ func marshalOrderedVDF(m *orderedmap.OrderedMap, indent int) (string, error) {
 		default:
 			// Fallback: print the value using fmt.
 			s := fmt.Sprintf("%v", v)
-			sb.WriteString(fmt.Sprintf("%s\"%s\"\n", spacing, s))
+			sb.WriteString(fmt.Sprintf("\t\t\"%s\"\n", escapeVDFString(s)))
 		}
 	}
 	return sb.String(), nil
 }
 
+// vdfLookup follows path through nested maps, matching keys case-insensitively
+// as Steam does.
+func vdfLookup(m *orderedmap.OrderedMap, path ...string) (interface{}, bool) {
+	var current interface{} = m
+	for _, key := range path {
+		currentMap, ok := current.(*orderedmap.OrderedMap)
+		if !ok || currentMap == nil {
+			return nil, false
+		}
+
+		found := false
+		for _, k := range currentMap.Keys() {
+			if strings.EqualFold(k, key) {
+				current, _ = currentMap.Get(k)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// vdfString returns the string at path, or "" if it is missing or not a string.
+func vdfString(m *orderedmap.OrderedMap, path ...string) string {
+	value, _ := vdfLookup(m, path...)
+	s, _ := value.(string)
+	return s
+}
+
 // Parses and unmarshals VDF file into map
 func Unmarshal(data []byte) (*orderedmap.OrderedMap, error) {
 	ordMap, _, err := parseVDFOrdered(string(data), 0)
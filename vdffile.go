@@ -0,0 +1,159 @@
+package steamutils
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/iancoleman/orderedmap"
+)
+
+// VDFBackupCount is the number of previous versions WriteVDFFile keeps next to
+// a file: path.bak is the newest, then path.bak.1 up to path.bak.<VDFBackupCount-1>.
+const VDFBackupCount = 5
+
+// WriteVDFFile marshals m and replaces the file at path with it.
+//
+// The new contents are written to a temporary file in the same directory,
+// synced to disk and renamed over path, so a crash leaves either the old or the
+// new file, never a partial one. An existing file's permissions and, on Linux
+// and macOS, its owner are kept; the write fails rather than leave a file Steam
+// cannot read because it now belongs to another user. The previous contents are
+// kept as rotating backups, see VDFBackupCount and RestoreVDFFile.
+//
+// WARNING: This function was generated by an LLM. Thoroughly test before production use.
+func WriteVDFFile(path string, m *orderedmap.OrderedMap) error {
+	data, err := Marshal(m)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", path, err)
+	}
+	return writeFileAtomic(path, data)
+}
+
+// RestoreVDFFile undoes the last n writes WriteVDFFile made to path: the
+// backup taken n writes ago becomes the current file, the current file and
+// the newer backups are discarded, and older backups move up. n must be between
+// 1 and VDFBackupCount and that backup must exist.
+//
+// WARNING: This function was generated by an LLM. Thoroughly test before production use.
+func RestoreVDFFile(path string, n int) error {
+	if n < 1 || n > VDFBackupCount {
+		return fmt.Errorf("can only restore 1 to %d versions back, not %d", VDFBackupCount, n)
+	}
+
+	data, err := os.ReadFile(vdfBackupPath(path, n-1))
+	if err != nil {
+		return fmt.Errorf("no backup %d versions back: %w", n, err)
+	}
+	if _, err := Unmarshal(data); err != nil {
+		return fmt.Errorf("backup %s does not parse: %w", vdfBackupPath(path, n-1), err)
+	}
+
+	info, err := os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		info, err = os.Stat(vdfBackupPath(path, n-1))
+	}
+	if err != nil {
+		return err
+	}
+	if err := replaceFile(path, data, info); err != nil {
+		return err
+	}
+
+	for i := 0; i < VDFBackupCount; i++ {
+		older := vdfBackupPath(path, i+n)
+		if i+n >= VDFBackupCount {
+			os.Remove(vdfBackupPath(path, i))
+			continue
+		}
+		if err := os.Rename(older, vdfBackupPath(path, i)); errors.Is(err, fs.ErrNotExist) {
+			os.Remove(vdfBackupPath(path, i))
+		} else if err != nil {
+			return fmt.Errorf("failed to renumber backups: %w", err)
+		}
+	}
+	return nil
+}
+
+// vdfBackupPath returns the name of the i-th most recent backup of path,
+// counting from 0.
+func vdfBackupPath(path string, i int) string {
+	if i == 0 {
+		return path + ".bak"
+	}
+	return fmt.Sprintf("%s.bak.%d", path, i)
+}
+
+// writeFileAtomic rotates the backups of path, copies its current contents to
+// path.bak and then replaces it with data.
+func writeFileAtomic(path string, data []byte) error {
+	info, err := os.Stat(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	if info != nil {
+		old, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		for i := VDFBackupCount - 1; i > 0; i-- {
+			err := os.Rename(vdfBackupPath(path, i-1), vdfBackupPath(path, i))
+			if err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return fmt.Errorf("failed to rotate backups: %w", err)
+			}
+		}
+		if err := replaceFile(vdfBackupPath(path, 0), old, info); err != nil {
+			return fmt.Errorf("failed to write backup: %w", err)
+		}
+	}
+
+	return replaceFile(path, data, info)
+}
+
+// replaceFile writes data to a temporary file next to path and renames it over
+// path. The result gets the permissions and owner of like, or mode 0644 and
+// the current user if like is nil.
+func replaceFile(path string, data []byte, like os.FileInfo) error {
+	perm := os.FileMode(0644)
+	if like != nil {
+		perm = like.Mode().Perm()
+	}
+
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+
+	_, err = tmp.Write(data)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmpPath, perm)
+	}
+	if err == nil && like != nil {
+		err = copyFileOwner(tmpPath, like)
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, path)
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	// Persist the rename itself; not every platform can sync a directory.
+	syncDir(dir)
+	return nil
+}
+
+// The code in this file was made by an LLM, use in production is highly discouraged as unexpected results may occur. The code in this file is not vetted for stability or edge cases.
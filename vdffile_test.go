@@ -0,0 +1,150 @@
+package steamutils
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/iancoleman/orderedmap"
+)
+
+// vdfVersion returns the marshaled form of version i of a test file.
+func vdfVersion(t *testing.T, i int) string {
+	t.Helper()
+	m := orderedmap.New()
+	block := orderedmap.New()
+	block.Set("version", strconv.Itoa(i))
+	m.Set("test", block)
+	data, err := Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+// writeVDFVersion writes version i of the test file with WriteVDFFile.
+func writeVDFVersion(t *testing.T, path string, i int) {
+	t.Helper()
+	m, err := Unmarshal([]byte(vdfVersion(t, i)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteVDFFile(path, m); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// checkVDFVersions checks that path holds version current and its backups
+// the given versions, newest first, with no further backups.
+func checkVDFVersions(t *testing.T, path string, current int, backups ...int) {
+	t.Helper()
+	if data, err := os.ReadFile(path); err != nil || string(data) != vdfVersion(t, current) {
+		t.Errorf("%s = %q, %v; want version %d", filepath.Base(path), data, err, current)
+	}
+	for i := 0; i < VDFBackupCount; i++ {
+		backup := vdfBackupPath(path, i)
+		data, err := os.ReadFile(backup)
+		if i >= len(backups) {
+			if err == nil {
+				t.Errorf("%s exists, want no backup %d", filepath.Base(backup), i)
+			}
+			continue
+		}
+		if err != nil || string(data) != vdfVersion(t, backups[i]) {
+			t.Errorf("%s = %q, %v; want version %d", filepath.Base(backup), data, err, backups[i])
+		}
+	}
+}
+
+func TestWriteVDFFileRotatesBackups(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.vdf")
+
+	writeVDFVersion(t, path, 0)
+	checkVDFVersions(t, path, 0)
+
+	if runtime.GOOS != "windows" {
+		if err := os.Chmod(path, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := 1; i <= VDFBackupCount+1; i++ {
+		writeVDFVersion(t, path, i)
+	}
+	// Version 0 has been rotated out.
+	checkVDFVersions(t, path, 6, 5, 4, 3, 2, 1)
+
+	if runtime.GOOS != "windows" {
+		for _, p := range []string{path, vdfBackupPath(path, 0)} {
+			if info, err := os.Stat(p); err != nil || info.Mode().Perm() != 0600 {
+				t.Errorf("%s mode = %v, %v; want 0600 kept", filepath.Base(p), info.Mode().Perm(), err)
+			}
+		}
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if strings.HasSuffix(entry.Name(), ".tmp") {
+			t.Errorf("temporary file %s left behind", entry.Name())
+		}
+	}
+}
+
+func TestRestoreVDFFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.vdf")
+	for i := 0; i <= VDFBackupCount+1; i++ {
+		writeVDFVersion(t, path, i)
+	}
+
+	if err := RestoreVDFFile(path, 2); err != nil {
+		t.Fatal(err)
+	}
+	checkVDFVersions(t, path, 4, 3, 2, 1)
+
+	if err := RestoreVDFFile(path, 1); err != nil {
+		t.Fatal(err)
+	}
+	checkVDFVersions(t, path, 3, 2, 1)
+
+	// Writing after a restore starts a new history from the restored file.
+	writeVDFVersion(t, path, 7)
+	checkVDFVersions(t, path, 7, 3, 2, 1)
+
+	for _, n := range []int{0, VDFBackupCount + 1, 4} {
+		if err := RestoreVDFFile(path, n); err == nil {
+			t.Errorf("RestoreVDFFile(%d) succeeded", n)
+		}
+	}
+
+	if err := os.WriteFile(vdfBackupPath(path, 0), []byte(`"test"`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := RestoreVDFFile(path, 1); err == nil {
+		t.Error("RestoreVDFFile succeeded with a backup that does not parse")
+	}
+	if data, _ := os.ReadFile(path); string(data) != vdfVersion(t, 7) {
+		t.Errorf("failed restore changed the file to %q", data)
+	}
+}
+
+func TestRestoreVDFFileMissingCurrent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.vdf")
+	writeVDFVersion(t, path, 0)
+	writeVDFVersion(t, path, 1)
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := RestoreVDFFile(path, 1); err != nil {
+		t.Fatal(err)
+	}
+	checkVDFVersions(t, path, 0)
+}
+
+// The code in this file was made by an LLM, use in production is highly discouraged as unexpected results may occur. The code in this file is not vetted for stability or edge cases.